@@ -0,0 +1,506 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Hawkeye-Claims/hawkeye-sdk-for-go/pkg/hawkeyesdk"
+)
+
+var (
+	claimColumns       = []string{"filenumber", "rentername", "dateofloss", "insurancecompany", "claimnumber", "vin"}
+	adminClaimColumns  = []string{"filenumber", "rentername", "dateofloss", "insurancecompany", "claimstatusname", "claimnumber"}
+	responseColumns    = []string{"success", "filenumber", "message", "error"}
+	docFileColumns     = []string{"doctype", "filename", "dateadded", "user"}
+	logTrailColumns    = []string{"time", "user", "activity"}
+	insCompanyColumns  = []string{"id", "name", "probability"}
+	importColumns      = []string{"line", "filenumber", "rentername", "carrier", "inscompany", "status", "error"}
+	claimFieldsByName  = map[string]hawkeyesdk.ClaimField{}
+	claimFieldsAllowed []string
+)
+
+func init() {
+	for _, f := range []hawkeyesdk.ClaimField{
+		hawkeyesdk.FieldFilenumber, hawkeyesdk.FieldDateOfLoss, hawkeyesdk.FieldLossMonth, hawkeyesdk.FieldLossYear,
+		hawkeyesdk.FieldInsuranceCompany, hawkeyesdk.FieldStatus, hawkeyesdk.FieldRenterName,
+	} {
+		name := strings.ToLower(f.String())
+		claimFieldsByName[name] = f
+		claimFieldsAllowed = append(claimFieldsAllowed, name)
+	}
+}
+
+func parseFilenumber(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, usageError("invalid file number %q", s)
+	}
+	return n, nil
+}
+
+func wantArgs(args []string, n int) error {
+	if len(args) != n {
+		return usageError("expected %d argument(s), got %d", n, len(args))
+	}
+	return nil
+}
+
+// checkResponse turns an unsuccessful ApiResponse into an error after it has
+// been printed, so scripts see a non-zero exit status.
+func checkResponse(resp hawkeyesdk.ApiResponse) error {
+	if !resp.Success {
+		return fmt.Errorf("request was not successful: %s", resp.Message)
+	}
+	return nil
+}
+
+func claimsGet(a *app, fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		if err := wantArgs(args, 1); err != nil {
+			return err
+		}
+		filenumber, err := parseFilenumber(args[0])
+		if err != nil {
+			return err
+		}
+		client, err := a.client()
+		if err != nil {
+			return err
+		}
+		claim, err := client.Claims.GetSingleClaim(ctx, filenumber)
+		if err != nil {
+			return err
+		}
+		return a.print(claim, claimColumns...)
+	}
+}
+
+func claimsList(a *app, fs *flag.FlagSet) runFunc {
+	inactive := fs.Bool("inactive", false, "include inactive claims")
+	pageSize := fs.Int("page-size", 0, "request claims in pages of this size")
+	return func(ctx context.Context, args []string) error {
+		if err := wantArgs(args, 0); err != nil {
+			return err
+		}
+		client, err := a.client()
+		if err != nil {
+			return err
+		}
+		claims, err := client.Claims.GetClaims(ctx, hawkeyesdk.WithIncludeInactive(*inactive), hawkeyesdk.WithPageSize(*pageSize))
+		if err != nil {
+			return err
+		}
+		return a.print(claims, claimColumns...)
+	}
+}
+
+// readClaimPost decodes a ClaimPost from path, or from stdin when path is
+// empty or "-". Unknown fields are rejected to catch misspelled keys.
+func (a *app) readClaimPost(path string) (hawkeyesdk.ClaimPost, error) {
+	var r io.Reader = a.stdin
+	if path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return hawkeyesdk.ClaimPost{}, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var claim hawkeyesdk.ClaimPost
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&claim); err != nil {
+		return hawkeyesdk.ClaimPost{}, fmt.Errorf("failed to read claim: %w", err)
+	}
+	return claim, nil
+}
+
+func claimsCreate(a *app, fs *flag.FlagSet) runFunc {
+	file := fs.String("f", "", "ClaimPost JSON file (default stdin)")
+	return func(ctx context.Context, args []string) error {
+		if err := wantArgs(args, 0); err != nil {
+			return err
+		}
+		claim, err := a.readClaimPost(*file)
+		if err != nil {
+			return err
+		}
+		client, err := a.client()
+		if err != nil {
+			return err
+		}
+		resp, err := client.Claims.CreateClaim(ctx, claim)
+		if err != nil {
+			return err
+		}
+		if err := a.print(resp, responseColumns...); err != nil {
+			return err
+		}
+		return checkResponse(resp)
+	}
+}
+
+func claimsUpdate(a *app, fs *flag.FlagSet) runFunc {
+	file := fs.String("f", "", "ClaimPost JSON file (default stdin)")
+	filenumber := fs.Int("filenumber", 0, "claim to update, overriding the payload's filenumber")
+	return func(ctx context.Context, args []string) error {
+		if err := wantArgs(args, 0); err != nil {
+			return err
+		}
+		claim, err := a.readClaimPost(*file)
+		if err != nil {
+			return err
+		}
+		if *filenumber > 0 {
+			claim.FileNumber = *filenumber
+		}
+		client, err := a.client()
+		if err != nil {
+			return err
+		}
+		resp, err := client.Claims.UpdateClaim(ctx, claim)
+		if err != nil {
+			return err
+		}
+		if err := a.print(resp, responseColumns...); err != nil {
+			return err
+		}
+		return checkResponse(resp)
+	}
+}
+
+// importRow is the printed outcome of one imported row.
+type importRow struct {
+	Line       int    `json:"line"`
+	Filenumber int    `json:"filenumber,omitempty"`
+	RenterName string `json:"rentername"`
+	Carrier    string `json:"carrier,omitempty"`
+	InsCompany string `json:"inscompany,omitempty"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+func claimsImport(a *app, fs *flag.FlagSet) runFunc {
+	dryRun := fs.Bool("dry-run", false, "validate the rows without creating claims")
+	allOrNothing := fs.Bool("all-or-nothing", false, "create no claims unless every row is valid")
+	concurrency := fs.Int("concurrency", 4, "claims created at once")
+	comma := fs.String("comma", ",", "field delimiter; use \\t for tabs")
+	var mappings stringList
+	fs.Var(&mappings, "map", "column mapping header=field, e.g. \"Loss Dt=dateofloss\" (repeatable)")
+
+	return func(ctx context.Context, args []string) error {
+		if len(args) > 1 {
+			return usageError("expected at most one file")
+		}
+
+		mapping := hawkeyesdk.ColumnMapping{}
+		for _, m := range mappings {
+			header, field, ok := strings.Cut(m, "=")
+			if !ok {
+				return usageError("-map: expected header=field, got %q", m)
+			}
+			mapping[strings.TrimSpace(header)] = strings.TrimSpace(field)
+		}
+		delim := []rune(strings.ReplaceAll(*comma, `\t`, "\t"))
+		if len(delim) != 1 {
+			return usageError("-comma: expected a single character, got %q", *comma)
+		}
+
+		var r io.Reader = a.stdin
+		if len(args) == 1 && args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+
+		client, err := a.client()
+		if err != nil {
+			return err
+		}
+		importer := hawkeyesdk.NewClaimImporter(client.Claims, client.InsCompanies,
+			hawkeyesdk.WithDryRun(*dryRun),
+			hawkeyesdk.WithAllOrNothing(*allOrNothing),
+			hawkeyesdk.WithImportConcurrency(*concurrency),
+			hawkeyesdk.WithImportComma(delim[0]),
+			hawkeyesdk.WithColumnMapping(mapping),
+		)
+		report, err := importer.Import(ctx, r)
+		if err != nil {
+			return err
+		}
+		if len(report.UnmappedColumns) > 0 {
+			fmt.Fprintf(a.stderr, "hawkeye: ignoring unmapped columns: %s\n", strings.Join(report.UnmappedColumns, ", "))
+		}
+
+		rows := make([]importRow, len(report.Results))
+		for i, res := range report.Results {
+			rows[i] = importRow{Line: res.Line, Filenumber: res.Filenumber, RenterName: res.Claim.RenterName, Carrier: res.Carrier, InsCompany: res.InsCompany, Status: "created"}
+			switch {
+			case res.Err != nil:
+				rows[i].Status, rows[i].Error = "failed", res.Err.Error()
+			case report.DryRun:
+				rows[i].Status = "valid"
+			}
+		}
+		if err := a.print(rows, importColumns...); err != nil {
+			return err
+		}
+		if failed := report.Failed(); failed > 0 {
+			return fmt.Errorf("%d of %d rows failed", failed, len(report.Results))
+		}
+		return nil
+	}
+}
+
+// stringList collects a repeatable flag.
+type stringList []string
+
+func (s *stringList) String() string { return strings.Join(*s, ",") }
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+func adminClaims(a *app, fs *flag.FlagSet) runFunc {
+	inactive := fs.Bool("inactive", false, "include inactive claims")
+	docfiles := fs.Bool("docfiles", false, "include documents")
+	logtrail := fs.Bool("logtrail", false, "include log trails")
+	filenumber := fs.Int("filenumber", 0, "only this claim")
+	from := fs.String("from", "", "date of loss on or after (YYYY-MM-DD)")
+	to := fs.String("to", "", "date of loss on or before (YYYY-MM-DD)")
+	search := fs.String("search", "", "search names, VIN, plate, unit, claim and RA numbers")
+	totalLoss := fs.String("total-loss", "", "true or false")
+	sortBy := fs.String("sort", "", "sort field: "+strings.Join(claimFieldsAllowed, ", "))
+	desc := fs.Bool("desc", false, "sort descending")
+	pageSize := fs.Int("page-size", 0, "request claims in pages of this size")
+	var carriers, statuses stringList
+	fs.Var(&carriers, "carrier", "insurance company (repeatable)")
+	fs.Var(&statuses, "status", "claim status name (repeatable)")
+
+	return func(ctx context.Context, args []string) error {
+		if err := wantArgs(args, 0); err != nil {
+			return err
+		}
+
+		q := hawkeyesdk.NewQuery[hawkeyesdk.AdminClaim]()
+		if *from != "" || *to != "" {
+			var low, high time.Time
+			for _, bound := range []struct {
+				flag  string
+				value string
+				dst   *time.Time
+			}{{"from", *from, &low}, {"to", *to, &high}} {
+				if bound.value == "" {
+					continue
+				}
+				d, err := hawkeyesdk.ParseDate(bound.value)
+				if err != nil {
+					return usageError("-%s: %v", bound.flag, err)
+				}
+				*bound.dst = d.Time
+			}
+			q.DateOfLossBetween(low, high)
+		}
+		if len(carriers) > 0 {
+			q.InsuranceCompany(carriers...)
+		}
+		if len(statuses) > 0 {
+			q.Status(statuses...)
+		}
+		if *totalLoss != "" {
+			b, err := strconv.ParseBool(*totalLoss)
+			if err != nil {
+				return usageError("-total-loss: %v", err)
+			}
+			q.TotalLoss(b)
+		}
+		if *search != "" {
+			q.Search(*search)
+		}
+		if *sortBy != "" {
+			field, ok := claimFieldsByName[strings.ToLower(*sortBy)]
+			if !ok {
+				return usageError("-sort: unknown field %q", *sortBy)
+			}
+			q.SortBy(field, *desc)
+		}
+
+		opts := []hawkeyesdk.GetAdminClaimsOption{
+			hawkeyesdk.WithAdminIncludeInactive(*inactive),
+			hawkeyesdk.WithDocFiles(*docfiles),
+			hawkeyesdk.WithLogTrail(*logtrail),
+			hawkeyesdk.WithAdminPageSize(*pageSize),
+		}
+		if *filenumber > 0 {
+			opts = append(opts, hawkeyesdk.WithFilenumber(filenumber))
+		}
+
+		client, err := a.client()
+		if err != nil {
+			return err
+		}
+		claims, err := q.Collect(client.Claims.IterateAdminClaims(ctx, opts...))
+		if err != nil {
+			return err
+		}
+		if claims == nil {
+			claims = []hawkeyesdk.AdminClaim{}
+		}
+		return a.print(claims, adminClaimColumns...)
+	}
+}
+
+func docsUpload(a *app, fs *flag.FlagSet) runFunc {
+	category := fs.String("category", "", "document type name or code")
+	notes := fs.String("notes", "", "notes")
+	visible := fs.Bool("visible", false, "visible to the client")
+	auto := fs.Bool("auto-category", false, "choose the document type from the file when -category is not set")
+	resumable := fs.Bool("resumable", false, "upload a local file in resumable chunks")
+	return func(ctx context.Context, args []string) error {
+		if err := wantArgs(args, 2); err != nil {
+			return err
+		}
+		filenumber, err := parseFilenumber(args[0])
+		if err != nil {
+			return err
+		}
+
+		opts := []hawkeyesdk.UploadFileOption{hawkeyesdk.WithVisibleToClient(*visible)}
+		if *category != "" {
+			docType, err := hawkeyesdk.ParseDocType(*category)
+			if err != nil {
+				return usageError("-category: %v", err)
+			}
+			opts = append(opts, hawkeyesdk.WithCategory(docType))
+		} else if *auto {
+			opts = append(opts, hawkeyesdk.WithAutoCategory(hawkeyesdk.NewDocTypeClassifier(), 0))
+		}
+		if *notes != "" {
+			opts = append(opts, hawkeyesdk.WithNotes(*notes))
+		}
+
+		client, err := a.client()
+		if err != nil {
+			return err
+		}
+
+		source := args[1]
+		var resp hawkeyesdk.ApiResponse
+		switch {
+		case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+			resp, err = client.DocFiles.UploadFileContext(ctx, filenumber, source, opts...)
+		case *resumable:
+			resp, err = client.DocFiles.UploadResumable(ctx, filenumber, source, opts...)
+		default:
+			resp, err = client.DocFiles.UploadPath(ctx, filenumber, source, opts...)
+		}
+		if err != nil {
+			return err
+		}
+		if err := a.print(resp, responseColumns...); err != nil {
+			return err
+		}
+		return checkResponse(resp)
+	}
+}
+
+func docsList(a *app, fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		if err := wantArgs(args, 1); err != nil {
+			return err
+		}
+		filenumber, err := parseFilenumber(args[0])
+		if err != nil {
+			return err
+		}
+		client, err := a.client()
+		if err != nil {
+			return err
+		}
+		docs, err := client.DocFiles.ListDocFiles(ctx, filenumber)
+		if err != nil {
+			return err
+		}
+		return a.print(docs, docFileColumns...)
+	}
+}
+
+func logTrailAdd(a *app, fs *flag.FlagSet) runFunc {
+	date := fs.String("date", "", "entry date (MM/DD/YYYY); default now")
+	return func(ctx context.Context, args []string) error {
+		if len(args) < 2 {
+			return usageError("expected a file number and an activity")
+		}
+		filenumber, err := parseFilenumber(args[0])
+		if err != nil {
+			return err
+		}
+		var opts []hawkeyesdk.LogTrailOption
+		if *date != "" {
+			opts = append(opts, hawkeyesdk.WithDate(*date))
+		}
+		client, err := a.client()
+		if err != nil {
+			return err
+		}
+		resp, err := client.LogTrails.CreateLogTrail(ctx, filenumber, strings.Join(args[1:], " "), opts...)
+		if err != nil {
+			return err
+		}
+		if err := a.print(resp, responseColumns...); err != nil {
+			return err
+		}
+		return checkResponse(resp)
+	}
+}
+
+func logTrailList(a *app, fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		if err := wantArgs(args, 1); err != nil {
+			return err
+		}
+		filenumber, err := parseFilenumber(args[0])
+		if err != nil {
+			return err
+		}
+		client, err := a.client()
+		if err != nil {
+			return err
+		}
+		entries, err := client.LogTrails.ListLogTrails(ctx, filenumber)
+		if err != nil {
+			return err
+		}
+		return a.print(entries, logTrailColumns...)
+	}
+}
+
+func insCompaniesSearch(a *app, fs *flag.FlagSet) runFunc {
+	limit := fs.Int("limit", 5, "maximum results (up to 20)")
+	return func(ctx context.Context, args []string) error {
+		if len(args) == 0 {
+			return usageError("expected a search query")
+		}
+		client, err := a.client()
+		if err != nil {
+			return err
+		}
+		companies, err := client.InsCompanies.GetInsuranceCompanies(ctx, hawkeyesdk.WithQueryParameters(strings.Join(args, " "), *limit))
+		if err != nil {
+			return err
+		}
+		return a.print(companies, insCompanyColumns...)
+	}
+}
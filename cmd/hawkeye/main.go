@@ -0,0 +1,242 @@
+// Command hawkeye is a command-line client for the Hawkeye API built on the
+// hawkeyesdk package.
+//
+//	hawkeye [global flags] <command> <subcommand> [flags] [args]
+//
+// Settings come from the same environment variables and config file as
+// hawkeyesdk.NewHawkeyeClientFromEnv; run "hawkeye help" for the commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Hawkeye-Claims/hawkeye-sdk-for-go/pkg/hawkeyesdk"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	app := &app{
+		stdin:     os.Stdin,
+		stdout:    os.Stdout,
+		stderr:    os.Stderr,
+		lookupEnv: os.LookupEnv,
+	}
+	os.Exit(app.run(ctx, os.Args[1:]))
+}
+
+type app struct {
+	stdin     io.Reader
+	stdout    io.Writer
+	stderr    io.Writer
+	lookupEnv func(string) (string, bool)
+
+	// Global flags, accepted before or after the command name.
+	profile    string
+	env        string
+	configFile string
+	output     string
+	columns    string
+	timeout    time.Duration
+}
+
+// errUsage marks errors caused by bad arguments; the usage is printed and
+// the exit status is 2.
+var errUsage = errors.New("usage")
+
+func usageError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+// runFunc runs a command with its positional arguments.
+type runFunc func(ctx context.Context, args []string) error
+
+// command registers its flags in setup and returns the function that runs
+// it once the flags are parsed.
+type command struct {
+	name    string
+	args    string
+	summary string
+	setup   func(a *app, fs *flag.FlagSet) runFunc
+}
+
+var commands = []command{
+	{name: "claims get", args: "<filenumber>", summary: "show one claim", setup: claimsGet},
+	{name: "claims list", summary: "list claims", setup: claimsList},
+	{name: "claims create", summary: "create a claim from a ClaimPost JSON file or stdin", setup: claimsCreate},
+	{name: "claims update", summary: "update a claim from a ClaimPost JSON file or stdin", setup: claimsUpdate},
+	{name: "claims import", args: "[file.csv]", summary: "create claims from a CSV file or stdin", setup: claimsImport},
+	{name: "admin claims query", summary: "query admin claims", setup: adminClaims},
+	{name: "docs upload", args: "<filenumber> <path|url>", summary: "upload a document", setup: docsUpload},
+	{name: "docs list", args: "<filenumber>", summary: "list a claim's documents", setup: docsList},
+	{name: "logtrail add", args: "<filenumber> <activity>", summary: "add a log trail entry", setup: logTrailAdd},
+	{name: "logtrail list", args: "<filenumber>", summary: "list a claim's log trail", setup: logTrailList},
+	{name: "inscompanies search", args: "<query>", summary: "search insurance companies", setup: insCompaniesSearch},
+}
+
+func (a *app) globalFlags(fs *flag.FlagSet) {
+	fs.StringVar(&a.profile, "profile", a.profile, "config profile (default $HAWKEYE_PROFILE)")
+	fs.StringVar(&a.env, "env", a.env, "environment: prod or dev")
+	fs.StringVar(&a.configFile, "config", a.configFile, "config file (default $HAWKEYE_CONFIG)")
+	fs.StringVar(&a.output, "output", a.output, "output format: table, json, csv or yaml")
+	fs.StringVar(&a.output, "o", a.output, "shorthand for -output")
+	fs.StringVar(&a.columns, "columns", a.columns, "comma-separated fields to show")
+	fs.DurationVar(&a.timeout, "timeout", a.timeout, "overall timeout")
+}
+
+func (a *app) run(ctx context.Context, args []string) int {
+	a.profile, a.env, a.configFile, a.columns, a.timeout = "", "", "", "", 0
+	a.output = "table"
+
+	global := flag.NewFlagSet("hawkeye", flag.ContinueOnError)
+	global.SetOutput(io.Discard)
+	a.globalFlags(global)
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			a.usage()
+			return 0
+		}
+		return a.fail(usageError("%v", err))
+	}
+	args = global.Args()
+
+	if len(args) == 0 || args[0] == "help" {
+		a.usage()
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	cmd, rest, ok := findCommand(args)
+	if !ok {
+		return a.fail(usageError("unknown command %q", strings.Join(args[:min(len(args), 3)], " ")))
+	}
+
+	fs := flag.NewFlagSet("hawkeye "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	a.globalFlags(fs)
+	run := cmd.setup(a, fs)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "usage: hawkeye %s [flags] %s\n\n%s.\n\nflags:\n", cmd.name, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(rest); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if _, err := a.printer(); err != nil {
+		fmt.Fprintf(a.stderr, "hawkeye: %v\n", err)
+		return 2
+	}
+
+	if a.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+	}
+
+	if err := run(ctx, fs.Args()); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(a.stderr, "hawkeye: %v\n", err)
+			fs.Usage()
+			return 2
+		}
+		return a.fail(err)
+	}
+	return 0
+}
+
+func findCommand(args []string) (command, []string, bool) {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) >= len(words) && slices.Equal(args[:len(words)], words) {
+			return cmd, args[len(words):], true
+		}
+	}
+	return command{}, nil, false
+}
+
+func (a *app) fail(err error) int {
+	fmt.Fprintf(a.stderr, "hawkeye: %v\n", err)
+	if errors.Is(err, errUsage) {
+		fmt.Fprintln(a.stderr, "run 'hawkeye help' for usage")
+		return 2
+	}
+	return 1
+}
+
+func (a *app) usage() {
+	fmt.Fprintln(a.stdout, "usage: hawkeye [global flags] <command> <subcommand> [flags] [args]")
+	fmt.Fprintln(a.stdout, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(a.stdout, "  %-38s %s\n", strings.TrimSpace(cmd.name+" "+cmd.args), cmd.summary)
+	}
+	fmt.Fprintln(a.stdout, "\nglobal flags:")
+	fs := flag.NewFlagSet("hawkeye", flag.ContinueOnError)
+	fs.SetOutput(a.stdout)
+	a.globalFlags(fs)
+	fs.PrintDefaults()
+	fmt.Fprintln(a.stdout, "\nThe token and other settings are read from HAWKEYE_* environment variables")
+	fmt.Fprintln(a.stdout, "and the config file; see the hawkeyesdk README.")
+}
+
+// client builds the SDK client from the config file, environment and flags.
+func (a *app) client() (*hawkeyesdk.ClientSettings, error) {
+	opts := []hawkeyesdk.ConfigOption{hawkeyesdk.WithConfigEnv(a.lookupEnv)}
+	if a.profile != "" {
+		opts = append(opts, hawkeyesdk.WithProfile(a.profile))
+	}
+	if a.configFile != "" {
+		opts = append(opts, hawkeyesdk.WithConfigFile(a.configFile))
+	}
+	cfg, err := hawkeyesdk.LoadConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if a.env != "" {
+		env, err := hawkeyesdk.ParseEnvironment(a.env)
+		if err != nil {
+			return nil, usageError("-env: %v", err)
+		}
+		// An explicit environment replaces any base URL from the profile.
+		cfg.Environment, cfg.BaseURL = env, ""
+	}
+	return hawkeyesdk.NewHawkeyeClientFromConfig(cfg)
+}
+
+func (a *app) printer() (*printer, error) {
+	format, err := parseOutputFormat(a.output)
+	if err != nil {
+		return nil, usageError("%v", err)
+	}
+	p := &printer{w: a.stdout, format: format}
+	for _, c := range strings.Split(a.columns, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			p.columns = append(p.columns, strings.ToLower(c))
+		}
+	}
+	return p, nil
+}
+
+// print writes v with the output flags; defaultColumns are used for tables.
+func (a *app) print(v any, defaultColumns ...string) error {
+	p, err := a.printer()
+	if err != nil {
+		return err
+	}
+	return p.print(v, defaultColumns...)
+}
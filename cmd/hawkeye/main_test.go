@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testClaims = `[
+	{"filenumber": 101, "rentername": "Ada Lovelace", "insurancecompany": "Acme Mutual", "claimnumber": "C-1", "dateofloss": "2024-03-01", "vin": "VIN1"},
+	{"filenumber": 102, "rentername": "Grace Hopper", "insurancecompany": "Beta Insurance", "claimnumber": "C-2", "dateofloss": "2024-05-09", "vin": "VIN2"}
+]`
+
+// newTestApp returns an app whose config file points the client at a test
+// server running handler, with uploads posted to /uploadfile and the "other"
+// profile using another token.
+func newTestApp(t *testing.T, handler http.HandlerFunc) (*app, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "config")
+	config := fmt.Sprintf("token = test-token\nbase_url = %s\nupload_endpoint = /uploadfile\nlogtrail_endpoint = /getlogtrail\n\n[other]\ntoken = other-token\n", server.URL)
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	a := &app{
+		stdin:  strings.NewReader(""),
+		stdout: &stdout,
+		stderr: &stderr,
+		lookupEnv: func(name string) (string, bool) {
+			if name == "HAWKEYE_CONFIG" {
+				return path, true
+			}
+			return "", false
+		},
+	}
+	return a, &stdout, &stderr
+}
+
+func TestRun_ClaimsListOutputs(t *testing.T) {
+	t.Parallel()
+
+	a, stdout, stderr := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/getclaims/all/true" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("unexpected authorization header %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(testClaims))
+	})
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{
+			args: []string{"claims", "list", "-inactive"},
+			want: "FILENUMBER  RENTERNAME    DATEOFLOSS  INSURANCECOMPANY  CLAIMNUMBER  VIN\n" +
+				"101         Ada Lovelace  2024-03-01  Acme Mutual       C-1          VIN1\n" +
+				"102         Grace Hopper  2024-05-09  Beta Insurance    C-2          VIN2\n",
+		},
+		{
+			args: []string{"-o", "csv", "claims", "list", "-inactive", "-columns", "filenumber,rentername"},
+			want: "filenumber,rentername\n101,Ada Lovelace\n102,Grace Hopper\n",
+		},
+		{
+			args: []string{"claims", "list", "-inactive", "--output", "yaml", "--columns", "filenumber,dateofloss"},
+			want: "- filenumber: 101\n  dateofloss: \"2024-03-01\"\n- filenumber: 102\n  dateofloss: \"2024-05-09\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		stdout.Reset()
+		if code := a.run(context.Background(), tt.args); code != 0 {
+			t.Fatalf("%v: expected exit status 0, got %d: %s", tt.args, code, stderr)
+		}
+		if stdout.String() != tt.want {
+			t.Fatalf("%v: expected output\n%s\ngot\n%s", tt.args, tt.want, stdout)
+		}
+	}
+
+	stdout.Reset()
+	if code := a.run(context.Background(), []string{"claims", "list", "-inactive", "-o", "json"}); code != 0 {
+		t.Fatalf("expected exit status 0, got %d: %s", code, stderr)
+	}
+	var claims []map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &claims); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(claims) != 2 || claims[1]["rentername"] != "Grace Hopper" {
+		t.Fatalf("unexpected JSON output %s", stdout)
+	}
+}
+
+func TestRun_ClaimsCreateFromStdin(t *testing.T) {
+	t.Parallel()
+
+	a, stdout, stderr := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/createclaim" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer other-token" {
+			t.Errorf("expected the profile's token, got %q", r.Header.Get("Authorization"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"rentername":"Ada Lovelace"`) {
+			t.Errorf("unexpected body %s", body)
+		}
+		w.Write([]byte(`{"filenumber": 555, "message": "created", "success": true}`))
+	})
+	a.stdin = strings.NewReader(`{
+		"rentername": "Ada Lovelace", "inscompaniesid": "12", "dateofloss": "2024-03-01",
+		"vehmake": "Ford", "vehmodel": "Focus", "vehcolor": "Blue", "vehvin": "1HGCM82633A004352"
+	}`)
+
+	if code := a.run(context.Background(), []string{"-profile", "other", "claims", "create", "-o", "json"}); code != 0 {
+		t.Fatalf("expected exit status 0, got %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout.String(), `"filenumber": 555`) {
+		t.Fatalf("unexpected output %s", stdout)
+	}
+
+	a.stdin = strings.NewReader(`{"renter_name": "Ada Lovelace"}`)
+	if code := a.run(context.Background(), []string{"claims", "create"}); code != 1 {
+		t.Fatalf("expected unknown fields to fail with status 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "renter_name") {
+		t.Fatalf("expected the unknown field to be reported, got %s", stderr)
+	}
+}
+
+func TestRun_AdminClaimsQuery(t *testing.T) {
+	t.Parallel()
+
+	a, stdout, stderr := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/getadminclaims" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`[
+			{"filenumber": 1, "rentername": "A", "insurancecompany": "Acme Mutual", "claimstatusname": "Open", "dateofloss": "2024-01-10"},
+			{"filenumber": 2, "rentername": "B", "insurancecompany": "Beta Insurance", "claimstatusname": "Open", "dateofloss": "2024-02-10"},
+			{"filenumber": 3, "rentername": "C", "insurancecompany": "Acme Mutual", "claimstatusname": "Open", "dateofloss": "2024-03-10"}
+		]`))
+	})
+
+	args := []string{"admin", "claims", "query", "-carrier", "Acme Mutual", "-from", "2024-01-01", "-sort", "filenumber", "-desc", "-o", "csv", "-columns", "filenumber"}
+	if code := a.run(context.Background(), args); code != 0 {
+		t.Fatalf("expected exit status 0, got %d: %s", code, stderr)
+	}
+	if want := "filenumber\n3\n1\n"; stdout.String() != want {
+		t.Fatalf("expected %q, got %q", want, stdout)
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	t.Parallel()
+
+	a, _, stderr := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	})
+
+	for _, args := range [][]string{
+		{"claims", "frobnicate"},
+		{"claims", "get"},
+		{"claims", "get", "abc"},
+		{"claims", "list", "-o", "xml"},
+		{"-env", "staging", "claims", "list"},
+		{"admin", "claims", "query", "-sort", "color"},
+	} {
+		stderr.Reset()
+		if code := a.run(context.Background(), args); code != 2 {
+			t.Fatalf("%v: expected exit status 2, got %d: %s", args, code, stderr)
+		}
+	}
+}
+
+func TestRun_ClaimsImportDryRun(t *testing.T) {
+	t.Parallel()
+
+	a, stdout, stderr := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/inscompanies" {
+			t.Errorf("expected a dry run to only look up carriers, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"query": "Acme", "suggestions": [{"id": 12, "name": "Acme Mutual"}]}`))
+	})
+	a.stdin = strings.NewReader("Renter\tVIN\tLoss Dt\tCarrier\tMake\tModel\tColor\n" +
+		"Ada Lovelace\t1HGCM82633A004352\t3/1/2024\tacme mutual\tHonda\tAccord\tBlue\n" +
+		"Grace Hopper\t1HGCM8263Q\t3/1/2024\tAcme Mutual\tHonda\tAccord\tBlue\n")
+
+	args := []string{"claims", "import", "-dry-run", "-comma", `\t`, "-map", "Loss Dt=dateofloss", "-o", "csv", "-columns", "line,inscompany,status"}
+	if code := a.run(context.Background(), args); code != 1 {
+		t.Fatalf("expected exit status 1 for the invalid row, got %d: %s", code, stderr)
+	}
+	if want := "line,inscompany,status\n2,Acme Mutual,valid\n3,Acme Mutual,failed\n"; stdout.String() != want {
+		t.Fatalf("expected %q, got %q", want, stdout)
+	}
+	if !strings.Contains(stderr.String(), "1 of 2 rows failed") {
+		t.Fatalf("unexpected stderr %s", stderr)
+	}
+}
+
+func TestRun_DocsLogTrailsAndCarriers(t *testing.T) {
+	t.Parallel()
+
+	a, stdout, stderr := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /uploadfile":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Errorf("expected a multipart upload, got %v", err)
+			}
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Errorf("expected a file part, got %v", err)
+				return
+			}
+			content, _ := io.ReadAll(file)
+			if r.FormValue("filenumber") != "101" || r.FormValue("notes") != "front bumper" || header.Filename != "estimate.txt" || string(content) != "estimate" {
+				t.Errorf("unexpected upload %v %q %q", r.MultipartForm.Value, header.Filename, content)
+			}
+			w.Write([]byte(`{"success": true}`))
+		case "GET /getadminclaims":
+			if r.URL.Query().Get("filenumber") != "101" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[{"filenumber": 101, "docfiles": [
+				{"doctype": 1, "filename": "report.pdf", "dateadded": "2024-03-02", "user": "ada"},
+				{"doctype": 0, "filename": "photo.jpg", "dateadded": "2024-03-05", "user": "grace"}
+			]}]`))
+		case "POST /createLogTrailEntry":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), `"activity":"Called the adjuster"`) || !strings.Contains(string(body), `"date":"03/04/2024"`) {
+				t.Errorf("unexpected body %s", body)
+			}
+			w.Write([]byte(`{"success": true, "filenumber": 101, "message": "added"}`))
+		case "GET /getlogtrail/101":
+			w.Write([]byte(`[
+				{"date": "2024-03-04", "activity": "Called the adjuster", "user": "ada"},
+				{"date": "2024-03-01", "activity": "Claim opened", "user": "grace"}
+			]`))
+		case "GET /inscompanies":
+			if r.URL.Query().Get("q") != "acme mutual" || r.URL.Query().Get("limit") != "2" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"query": "acme mutual", "suggestions": [{"id": 12, "name": "Acme Mutual", "probability": 90}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+
+	upload := filepath.Join(t.TempDir(), "estimate.txt")
+	if err := os.WriteFile(upload, []byte("estimate"), 0o600); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "docs upload",
+			args: []string{"docs", "upload", "-notes", "front bumper", "-o", "csv", "-columns", "success,message", "101", upload},
+			want: "success,message\ntrue,File uploaded successfully\n",
+		},
+		{
+			name: "docs list",
+			args: []string{"docs", "list", "101"},
+			want: "DOCTYPE                     FILENAME    DATEADDED   USER\n" +
+				"1st Report                  report.pdf  2024-03-02  ada\n" +
+				"Uncategorized API Document  photo.jpg   2024-03-05  grace\n",
+		},
+		{
+			name: "logtrail add",
+			args: []string{"logtrail", "add", "-date", "03/04/2024", "-o", "csv", "-columns", "success,message", "101", "Called", "the", "adjuster"},
+			want: "success,message\ntrue,added\n",
+		},
+		{
+			name: "logtrail list",
+			args: []string{"logtrail", "list", "101"},
+			want: "TIME                  USER   ACTIVITY\n" +
+				"2024-03-01T00:00:00Z  grace  Claim opened\n" +
+				"2024-03-04T00:00:00Z  ada    Called the adjuster\n",
+		},
+		{
+			name: "logtrail list json",
+			args: []string{"logtrail", "list", "-o", "json", "-columns", "user,activity", "101"},
+			want: "[\n  {\n    \"user\": \"grace\",\n    \"activity\": \"Claim opened\"\n  },\n" +
+				"  {\n    \"user\": \"ada\",\n    \"activity\": \"Called the adjuster\"\n  }\n]\n",
+		},
+		{
+			name: "inscompanies search",
+			args: []string{"inscompanies", "search", "-limit", "2", "-o", "csv", "acme", "mutual"},
+			want: "id,name,probability\n12,Acme Mutual,90\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout.Reset()
+			stderr.Reset()
+			if code := a.run(context.Background(), tt.args); code != 0 {
+				t.Fatalf("expected exit status 0, got %d: %s", code, stderr)
+			}
+			if stdout.String() != tt.want {
+				t.Fatalf("expected output\n%s\ngot\n%s", tt.want, stdout)
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+type outputFormat string
+
+const (
+	outputTable outputFormat = "table"
+	outputJSON  outputFormat = "json"
+	outputCSV   outputFormat = "csv"
+	outputYAML  outputFormat = "yaml"
+)
+
+func parseOutputFormat(s string) (outputFormat, error) {
+	switch f := outputFormat(strings.ToLower(s)); f {
+	case outputTable, outputJSON, outputCSV, outputYAML:
+		return f, nil
+	case "yml":
+		return outputYAML, nil
+	}
+	return "", fmt.Errorf("unknown output format %q (want table, json, csv or yaml)", s)
+}
+
+// field is one key of a decoded JSON object, kept in document order so that
+// tables and YAML follow the struct field order.
+type field struct {
+	key   string
+	value any
+}
+
+type object []field
+
+func (o object) get(key string) (any, bool) {
+	for _, f := range o {
+		if f.key == key {
+			return f.value, true
+		}
+	}
+	return nil, false
+}
+
+// toOrdered converts v to nested object, []any, json.Number, string, bool
+// and nil values through its JSON encoding.
+func toOrdered(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeOrdered(dec)
+}
+
+func decodeOrdered(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+	switch delim {
+	case '{':
+		obj := object{}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, field{key: keyTok.(string), value: value})
+		}
+		_, err := dec.Token()
+		return obj, err
+	case '[':
+		list := []any{}
+		for dec.More() {
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err := dec.Token()
+		return list, err
+	}
+	return nil, fmt.Errorf("unexpected delimiter %v", delim)
+}
+
+// printer writes command results in the selected format. columns limits
+// table and CSV output, and also JSON and YAML output when set explicitly.
+type printer struct {
+	w       io.Writer
+	format  outputFormat
+	columns []string
+}
+
+// print writes v. defaultColumns are used for tables when no columns were
+// requested, since claims have too many fields to show side by side.
+func (p *printer) print(v any, defaultColumns ...string) error {
+	if p.format == outputJSON && len(p.columns) == 0 {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	ordered, err := toOrdered(v)
+	if err != nil {
+		return err
+	}
+
+	columns := p.columns
+	if len(columns) > 0 {
+		ordered = project(ordered, columns)
+	}
+
+	switch p.format {
+	case outputJSON:
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(toPlain(ordered))
+	case outputYAML:
+		var buf bytes.Buffer
+		writeYAML(&buf, ordered, 0)
+		_, err := p.w.Write(buf.Bytes())
+		return err
+	}
+
+	rows := asRows(ordered)
+	if len(columns) == 0 && p.format == outputTable {
+		columns = defaultColumns
+	}
+	if len(columns) == 0 {
+		columns = columnsOf(rows)
+	}
+
+	if p.format == outputCSV {
+		w := csv.NewWriter(p.w)
+		w.Write(columns)
+		for _, row := range rows {
+			w.Write(cells(row, columns))
+		}
+		w.Flush()
+		return w.Error()
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(cells(row, columns), "\t"))
+	}
+	return tw.Flush()
+}
+
+// project keeps only columns of each object.
+func project(v any, columns []string) any {
+	switch v := v.(type) {
+	case object:
+		out := object{}
+		for _, c := range columns {
+			value, _ := v.get(c)
+			out = append(out, field{key: c, value: value})
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i := range v {
+			out[i] = project(v[i], columns)
+		}
+		return out
+	}
+	return v
+}
+
+func asRows(v any) []object {
+	switch v := v.(type) {
+	case object:
+		return []object{v}
+	case []any:
+		rows := make([]object, 0, len(v))
+		for _, e := range v {
+			if obj, ok := e.(object); ok {
+				rows = append(rows, obj)
+			} else {
+				rows = append(rows, object{{key: "value", value: e}})
+			}
+		}
+		return rows
+	}
+	return []object{{{key: "value", value: v}}}
+}
+
+func columnsOf(rows []object) []string {
+	seen := map[string]bool{}
+	var columns []string
+	for _, row := range rows {
+		for _, f := range row {
+			if !seen[f.key] {
+				seen[f.key] = true
+				columns = append(columns, f.key)
+			}
+		}
+	}
+	return columns
+}
+
+func cells(row object, columns []string) []string {
+	out := make([]string, len(columns))
+	for i, c := range columns {
+		value, _ := row.get(c)
+		out[i] = scalarText(value)
+	}
+	return out
+}
+
+// scalarText renders a value for a table or CSV cell; nested values are
+// written as compact JSON.
+func scalarText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	data, _ := json.Marshal(toPlain(v))
+	return string(data)
+}
+
+// toPlain turns ordered objects back into values encoding/json can marshal
+// in order.
+func toPlain(v any) any {
+	switch v := v.(type) {
+	case object:
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i, f := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(f.key)
+			value, _ := json.Marshal(toPlain(f.value))
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+		return json.RawMessage(buf.Bytes())
+	case []any:
+		out := make([]any, len(v))
+		for i := range v {
+			out[i] = toPlain(v[i])
+		}
+		return out
+	}
+	return v
+}
+
+func writeYAML(w *bytes.Buffer, v any, indent int) {
+	pad := strings.Repeat("  ", indent)
+	switch v := v.(type) {
+	case object:
+		if len(v) == 0 {
+			w.WriteString(pad + "{}\n")
+			return
+		}
+		for _, f := range v {
+			w.WriteString(pad + yamlString(f.key) + ":")
+			writeYAMLChild(w, f.value, indent+1)
+		}
+	case []any:
+		if len(v) == 0 {
+			w.WriteString(pad + "[]\n")
+			return
+		}
+		for _, e := range v {
+			// Objects start on the dash line: "- key: value".
+			if obj, ok := e.(object); ok && len(obj) > 0 {
+				var item bytes.Buffer
+				writeYAML(&item, obj, indent+1)
+				w.WriteString(pad + "- ")
+				w.Write(item.Bytes()[len(pad)+2:])
+				continue
+			}
+			w.WriteString(pad + "-")
+			writeYAMLChild(w, e, indent+1)
+		}
+	default:
+		w.WriteString(pad + yamlScalar(v) + "\n")
+	}
+}
+
+// writeYAMLChild writes the value following a "key:" or "-" prefix.
+func writeYAMLChild(w *bytes.Buffer, v any, indent int) {
+	switch c := v.(type) {
+	case object:
+		if len(c) > 0 {
+			w.WriteString("\n")
+			writeYAML(w, c, indent)
+			return
+		}
+		w.WriteString(" {}\n")
+	case []any:
+		if len(c) > 0 {
+			w.WriteString("\n")
+			writeYAML(w, c, indent)
+			return
+		}
+		w.WriteString(" []\n")
+	default:
+		w.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+func yamlScalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return yamlString(v)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return yamlString(fmt.Sprint(v))
+}
+
+// yamlString quotes s when a plain scalar would be read back as something
+// else, using JSON escapes, which are valid in double-quoted YAML.
+func yamlString(s string) string {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s, ":#{}[],&*?|<>=!%@`\"'\\\n\t") ||
+		strings.HasPrefix(s, "-") {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~", "y", "n":
+		return strconv.Quote(s)
+	}
+	// Numbers, dates and times would not be read back as strings.
+	if c := s[0]; c >= '0' && c <= '9' || c == '.' || c == '+' {
+		return strconv.Quote(s)
+	}
+	return s
+}
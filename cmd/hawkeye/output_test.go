@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrinter_YAML(t *testing.T) {
+	t.Parallel()
+
+	v := map[string]any{
+		"empty":  []int{},
+		"nested": map[string]any{"notes": "yes", "count": 2},
+		"list":   []any{"a: b", true, nil},
+		"text":   "-leading dash",
+	}
+
+	var buf bytes.Buffer
+	p := &printer{w: &buf, format: outputYAML}
+	if err := p.print(v); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := "empty: []\n" +
+		"list:\n  - \"a: b\"\n  - true\n  - null\n" +
+		"nested:\n  count: 2\n  notes: \"yes\"\n" +
+		"text: \"-leading dash\"\n"
+	if buf.String() != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, buf.String())
+	}
+}
+
+func TestPrinter_TableNestedValues(t *testing.T) {
+	t.Parallel()
+
+	v := []map[string]any{
+		{"id": 1, "tags": []string{"x", "y"}},
+		{"id": 2},
+	}
+
+	var buf bytes.Buffer
+	p := &printer{w: &buf, format: outputTable}
+	if err := p.print(v); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := "ID  TAGS\n1   [\"x\",\"y\"]\n2   \n"
+	if buf.String() != want {
+		t.Fatalf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	if f, err := parseOutputFormat("YML"); err != nil || f != outputYAML {
+		t.Fatalf("expected yaml, got %q, %v", f, err)
+	}
+	if _, err := parseOutputFormat("xml"); err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/Hawkeye-Claims/hawkeye-sdk-for-go
 
-go 1.22.2
+go 1.24
@@ -0,0 +1,274 @@
+package hawkeyesdk
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type ClaimKind int
+
+const (
+	FirstPartyClaim ClaimKind = iota
+	ThirdPartyClaim
+	CDWClaim
+	TotalLossClaim
+)
+
+func (k ClaimKind) String() string {
+	switch k {
+	case FirstPartyClaim:
+		return "First Party"
+	case ThirdPartyClaim:
+		return "Third Party"
+	case CDWClaim:
+		return "CDW"
+	case TotalLossClaim:
+		return "Total Loss"
+	default:
+		return fmt.Sprintf("ClaimKind(%d)", int(k))
+	}
+}
+
+// ClaimKinds derives the kinds of a claim from its FirstParty, ThirdParty, CDW
+// and TotalLoss flags, falling back to the free-text ClaimType. A total loss
+// is usually also first or third party, so several kinds can apply.
+func (a *AdminClaim) ClaimKinds() []ClaimKind {
+	var kinds []ClaimKind
+	claimType := normalizeDocTypeName(a.ClaimType)
+	has := func(flag bool, words ...string) bool {
+		return flag || slices.ContainsFunc(words, func(w string) bool {
+			return strings.Contains(claimType, w)
+		})
+	}
+	if has(a.FirstParty, "first party", "1st party") {
+		kinds = append(kinds, FirstPartyClaim)
+	}
+	if has(a.ThirdParty, "third party", "3rd party") {
+		kinds = append(kinds, ThirdPartyClaim)
+	}
+	if has(a.CDW, "cdw", "collision damage waiver") {
+		kinds = append(kinds, CDWClaim)
+	}
+	if has(a.TotalLoss, "total loss") {
+		kinds = append(kinds, TotalLossClaim)
+	}
+	return kinds
+}
+
+// DocumentRequirement describes one checklist entry. Flag reports the
+// matching AdminClaim checkbox and is nil for documents without one; any of
+// DocTypes satisfies the requirement.
+type DocumentRequirement struct {
+	Name     string
+	Flag     func(*AdminClaim) bool
+	DocTypes []DocType
+}
+
+// Names of the built-in document requirements.
+const (
+	RequirePolicy          = "policy"
+	RequireAOB             = "aob"
+	RequirePOA             = "poa"
+	RequirePhotos          = "photos"
+	RequireRentalAgreement = "rental agreement"
+	RequirePoliceReport    = "police report"
+	RequireEstimate        = "estimate"
+	RequireDV              = "dv"
+	RequireTitle           = "title"
+	RequireValuation       = "valuation"
+)
+
+func defaultDocumentRequirements() []DocumentRequirement {
+	return []DocumentRequirement{
+		{Name: RequirePolicy, Flag: func(a *AdminClaim) bool { return a.PolicyReceived }, DocTypes: []DocType{POLICY, INSURANCE_CARD}},
+		{Name: RequireAOB, Flag: func(a *AdminClaim) bool { return a.AOB }, DocTypes: []DocType{AOB}},
+		{Name: RequirePOA, Flag: func(a *AdminClaim) bool { return a.POA }, DocTypes: []DocType{POA}},
+		{Name: RequirePhotos, Flag: func(a *AdminClaim) bool { return a.Photos }, DocTypes: []DocType{IMAGES}},
+		{Name: RequireRentalAgreement, Flag: func(a *AdminClaim) bool { return a.RentalAgreement }, DocTypes: []DocType{RENTAL_AGREEMENT, TRAILER_INTERCHANGE_AGREEMENT}},
+		{Name: RequirePoliceReport, Flag: func(a *AdminClaim) bool { return a.PoliceReportReceived }, DocTypes: []DocType{POLICE_REPORT}},
+		{Name: RequireEstimate, Flag: func(a *AdminClaim) bool { return a.Estimate }, DocTypes: []DocType{DAMAGE_ASSESSMENT, HC_DAMAGE_APPRAISAL, NON_HC_DAMAGE_APPRAISAL}},
+		{Name: RequireDV, Flag: func(a *AdminClaim) bool { return a.DV }, DocTypes: []DocType{DV_FORM}},
+		{Name: RequireTitle, DocTypes: []DocType{TITLE}},
+		{Name: RequireValuation, DocTypes: []DocType{MARKET_VALUATION}},
+	}
+}
+
+func defaultChecklistRules() map[ClaimKind][]string {
+	return map[ClaimKind][]string{
+		FirstPartyClaim: {RequirePolicy, RequireRentalAgreement, RequirePhotos, RequireEstimate, RequirePoliceReport},
+		ThirdPartyClaim: {RequireRentalAgreement, RequirePhotos, RequireEstimate, RequirePoliceReport},
+		CDWClaim:        {RequireRentalAgreement, RequirePhotos, RequireEstimate},
+		TotalLossClaim:  {RequireTitle, RequireValuation, RequirePhotos},
+	}
+}
+
+type ChecklistStatus int
+
+const (
+	// DocumentPresent means the document was uploaded and, when the
+	// requirement has a flag, the flag is set.
+	DocumentPresent ChecklistStatus = iota
+	// DocumentMissing means there is neither a flag nor a matching upload.
+	DocumentMissing
+	// DocumentFlagWithoutFile means the claim flag is set but no matching
+	// document was uploaded.
+	DocumentFlagWithoutFile
+	// DocumentFileWithoutFlag means a matching document was uploaded but the
+	// claim flag is not set.
+	DocumentFileWithoutFlag
+)
+
+func (s ChecklistStatus) String() string {
+	switch s {
+	case DocumentPresent:
+		return "present"
+	case DocumentMissing:
+		return "missing"
+	case DocumentFlagWithoutFile:
+		return "flag set without file"
+	case DocumentFileWithoutFlag:
+		return "file without flag"
+	default:
+		return fmt.Sprintf("ChecklistStatus(%d)", int(s))
+	}
+}
+
+// Inconsistent reports whether the claim flag and the uploads disagree.
+func (s ChecklistStatus) Inconsistent() bool {
+	return s == DocumentFlagWithoutFile || s == DocumentFileWithoutFlag
+}
+
+type ChecklistItem struct {
+	Requirement string
+	Required    bool
+	Status      ChecklistStatus
+	Files       []DocFile
+}
+
+type ChecklistReport struct {
+	Filenumber int
+	Kinds      []ClaimKind
+	Items      []ChecklistItem
+}
+
+// Missing returns the required documents that were not uploaded, including
+// those whose flag is set without a file.
+func (r ChecklistReport) Missing() []ChecklistItem {
+	return r.filter(func(item ChecklistItem) bool {
+		return item.Required && (item.Status == DocumentMissing || item.Status == DocumentFlagWithoutFile)
+	})
+}
+
+// Inconsistent returns every item, required or not, whose flag disagrees
+// with the uploaded documents.
+func (r ChecklistReport) Inconsistent() []ChecklistItem {
+	return r.filter(func(item ChecklistItem) bool {
+		return item.Status.Inconsistent()
+	})
+}
+
+// Complete reports whether every required document is present and no flag
+// disagrees with the uploads.
+func (r ChecklistReport) Complete() bool {
+	return len(r.Missing()) == 0 && len(r.Inconsistent()) == 0
+}
+
+func (r ChecklistReport) filter(keep func(ChecklistItem) bool) []ChecklistItem {
+	var items []ChecklistItem
+	for _, item := range r.Items {
+		if keep(item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Checklist compares the document flags of admin claims with their uploaded
+// documents. The zero value is not usable; create one with NewChecklist.
+type Checklist struct {
+	requirements []DocumentRequirement
+	rules        map[ClaimKind][]string
+}
+
+// NewChecklist returns a checklist with the built-in requirements and rule
+// sets.
+func NewChecklist() *Checklist {
+	return &Checklist{
+		requirements: defaultDocumentRequirements(),
+		rules:        defaultChecklistRules(),
+	}
+}
+
+// AddRequirement registers a requirement, replacing any built-in one with the
+// same name.
+func (c *Checklist) AddRequirement(req DocumentRequirement) {
+	if i := slices.IndexFunc(c.requirements, func(r DocumentRequirement) bool { return r.Name == req.Name }); i >= 0 {
+		c.requirements[i] = req
+		return
+	}
+	c.requirements = append(c.requirements, req)
+}
+
+// SetRules replaces the names of the documents required for kind.
+func (c *Checklist) SetRules(kind ClaimKind, requirements ...string) error {
+	for _, name := range requirements {
+		if !slices.ContainsFunc(c.requirements, func(r DocumentRequirement) bool { return r.Name == name }) {
+			return fmt.Errorf("unknown document requirement: %s", name)
+		}
+	}
+	c.rules[kind] = slices.Clone(requirements)
+	return nil
+}
+
+// Check evaluates claim against docs, typically claim.DocFiles or the result
+// of DocFilesService.ListDocFiles. Every requirement is reported so that
+// inconsistent flags surface even when the document is not required.
+func (c *Checklist) Check(claim *AdminClaim, docs []DocFile) ChecklistReport {
+	kinds := claim.ClaimKinds()
+	required := make(map[string]bool)
+	for _, kind := range kinds {
+		for _, name := range c.rules[kind] {
+			required[name] = true
+		}
+	}
+
+	report := ChecklistReport{Filenumber: claim.Filenumber, Kinds: kinds}
+	for _, req := range c.requirements {
+		item := ChecklistItem{Requirement: req.Name, Required: required[req.Name]}
+		for _, doc := range docs {
+			if slices.Contains(req.DocTypes, doc.Doctype) {
+				item.Files = append(item.Files, doc)
+			}
+		}
+
+		hasFile := len(item.Files) > 0
+		switch {
+		case req.Flag == nil && hasFile:
+			item.Status = DocumentPresent
+		case req.Flag == nil:
+			item.Status = DocumentMissing
+		case req.Flag(claim) && hasFile:
+			item.Status = DocumentPresent
+		case req.Flag(claim):
+			item.Status = DocumentFlagWithoutFile
+		case hasFile:
+			item.Status = DocumentFileWithoutFlag
+		default:
+			item.Status = DocumentMissing
+		}
+
+		report.Items = append(report.Items, item)
+	}
+
+	return report
+}
+
+// CheckAll checks each claim against its own DocFiles.
+func (c *Checklist) CheckAll(claims []AdminClaim) []ChecklistReport {
+	reports := make([]ChecklistReport, 0, len(claims))
+	for i := range claims {
+		reports = append(reports, c.Check(&claims[i], claims[i].DocFiles))
+	}
+	return reports
+}
@@ -0,0 +1,130 @@
+package hawkeyesdk
+
+import (
+	"slices"
+	"testing"
+)
+
+func checklistItem(t *testing.T, report ChecklistReport, name string) ChecklistItem {
+	t.Helper()
+
+	for _, item := range report.Items {
+		if item.Requirement == name {
+			return item
+		}
+	}
+	t.Fatalf("no checklist item %q in %+v", name, report.Items)
+	return ChecklistItem{}
+}
+
+func TestChecklist_Check(t *testing.T) {
+	t.Parallel()
+
+	claim := &AdminClaim{
+		Filenumber:      42,
+		FirstParty:      true,
+		RentalAgreement: true,
+		Photos:          true,
+		Estimate:        true,
+		AOB:             true,
+		DocFiles: []DocFile{
+			{Doctype: RENTAL_AGREEMENT, Filename: "ra.pdf"},
+			{Doctype: IMAGES, Filename: "front.jpg"},
+			{Doctype: IMAGES, Filename: "rear.jpg"},
+			{Doctype: POLICE_REPORT, Filename: "police.pdf"},
+			{Doctype: POA, Filename: "poa.pdf"},
+		},
+	}
+
+	report := NewChecklist().Check(claim, claim.DocFiles)
+
+	if report.Filenumber != 42 || !slices.Equal(report.Kinds, []ClaimKind{FirstPartyClaim}) {
+		t.Fatalf("unexpected report header: %+v", report)
+	}
+
+	expected := map[string]struct {
+		status   ChecklistStatus
+		required bool
+	}{
+		RequireRentalAgreement: {DocumentPresent, true},
+		RequirePhotos:          {DocumentPresent, true},
+		RequireEstimate:        {DocumentFlagWithoutFile, true},
+		RequirePoliceReport:    {DocumentFileWithoutFlag, true},
+		RequirePolicy:          {DocumentMissing, true},
+		RequireAOB:             {DocumentFlagWithoutFile, false},
+		RequirePOA:             {DocumentFileWithoutFlag, false},
+		RequireDV:              {DocumentMissing, false},
+	}
+	for name, want := range expected {
+		item := checklistItem(t, report, name)
+		if item.Status != want.status || item.Required != want.required {
+			t.Fatalf("%s: got %v (required %v), expected %v (required %v)", name, item.Status, item.Required, want.status, want.required)
+		}
+	}
+	if files := checklistItem(t, report, RequirePhotos).Files; len(files) != 2 {
+		t.Fatalf("expected both photos to be matched, got %+v", files)
+	}
+
+	var missing []string
+	for _, item := range report.Missing() {
+		missing = append(missing, item.Requirement)
+	}
+	slices.Sort(missing)
+	if !slices.Equal(missing, []string{RequireEstimate, RequirePolicy}) {
+		t.Fatalf("unexpected missing documents: %v", missing)
+	}
+	if len(report.Inconsistent()) != 4 {
+		t.Fatalf("expected 4 inconsistent items, got %+v", report.Inconsistent())
+	}
+	if report.Complete() {
+		t.Fatalf("expected incomplete report")
+	}
+}
+
+func TestChecklist_CustomRules(t *testing.T) {
+	t.Parallel()
+
+	checklist := NewChecklist()
+	checklist.AddRequirement(DocumentRequirement{Name: "lienholder", DocTypes: []DocType{LIENHOLDER_INFO}})
+	if err := checklist.SetRules(TotalLossClaim, RequireTitle, "lienholder"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := checklist.SetRules(CDWClaim, "crystal ball"); err == nil {
+		t.Fatalf("expected unknown requirement to be rejected")
+	}
+
+	// The rules are copied, so later changes to the caller's slice do not leak in.
+	names := []string{RequireTitle}
+	if err := checklist.SetRules(CDWClaim, names...); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	names[0] = "crystal ball"
+	if checklist.rules[CDWClaim][0] != RequireTitle {
+		t.Fatalf("expected SetRules to keep its own copy, got %v", checklist.rules[CDWClaim])
+	}
+
+	claims := []AdminClaim{
+		{Filenumber: 1, ClaimType: "Total Loss", DocFiles: []DocFile{{Doctype: TITLE}, {Doctype: LIENHOLDER_INFO}}},
+		{Filenumber: 2, ClaimType: "Total Loss", DocFiles: []DocFile{{Doctype: TITLE}}},
+	}
+	reports := checklist.CheckAll(claims)
+
+	if !reports[0].Complete() {
+		t.Fatalf("expected complete report, got missing %+v inconsistent %+v", reports[0].Missing(), reports[0].Inconsistent())
+	}
+	if missing := reports[1].Missing(); len(missing) != 1 || missing[0].Requirement != "lienholder" {
+		t.Fatalf("expected lienholder to be missing, got %+v", missing)
+	}
+}
+
+func TestAdminClaim_ClaimKinds(t *testing.T) {
+	t.Parallel()
+
+	claim := AdminClaim{ThirdParty: true, ClaimType: "CDW / total-loss"}
+	if kinds := claim.ClaimKinds(); !slices.Equal(kinds, []ClaimKind{ThirdPartyClaim, CDWClaim, TotalLossClaim}) {
+		t.Fatalf("unexpected kinds: %v", kinds)
+	}
+	if kinds := (&AdminClaim{}).ClaimKinds(); len(kinds) != 0 {
+		t.Fatalf("expected no kinds, got %v", kinds)
+	}
+}
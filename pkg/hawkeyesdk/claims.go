@@ -1,14 +1,12 @@
 package hawkeyesdk
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
-	"strings"
+	"time"
 )
 
 type ClaimPost struct {
@@ -57,26 +55,17 @@ func ClaimPostRequiredFields() []string {
 	return fields
 }
 
-func (c ClaimPost) ValidateForCreate() error {
-	var missing []string
-
-	for _, def := range claimPostRequiredFieldDefs {
-		if strings.TrimSpace(def.accessor(c)) == "" {
-			missing = append(missing, def.name)
-		}
-	}
-
-	if len(missing) > 0 {
-		return fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
-	}
-
-	return nil
+// ValidateForCreate checks the required fields and the format of every field
+// that is set. Failures are reported as a *ValidationError.
+func (c ClaimPost) ValidateForCreate(opts ...ValidationOption) error {
+	return c.validateForCreate(time.Now(), opts...)
 }
 
 type GetClaimsOption func(*getClaimsOptions)
 
 type getClaimsOptions struct {
 	includeInactive bool
+	pageSize        int
 }
 
 func WithIncludeInactive(include bool) GetClaimsOption {
@@ -85,6 +74,13 @@ func WithIncludeInactive(include bool) GetClaimsOption {
 	}
 }
 
+// WithPageSize requests claims from the server in pages of size claims.
+func WithPageSize(size int) GetClaimsOption {
+	return func(opts *getClaimsOptions) {
+		opts.pageSize = size
+	}
+}
+
 type ClaimsService struct {
 	client *ClientSettings
 }
@@ -97,7 +93,7 @@ func NewClaimsService(client *ClientSettings) *ClaimsService {
 func (s *ClaimsService) CreateClaim(ctx context.Context, claim ClaimPost) (ApiResponse, error) {
 	var apiResp ApiResponse
 
-	if err := claim.ValidateForCreate(); err != nil {
+	if err := claim.ValidateForCreate(s.client.validationOptions()...); err != nil {
 		return apiResp, fmt.Errorf("claim validation failed: %w", err)
 	}
 
@@ -106,105 +102,67 @@ func (s *ClaimsService) CreateClaim(ctx context.Context, claim ClaimPost) (ApiRe
 		return apiResp, fmt.Errorf("failed to marshal claim data: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.client.BaseUrl+"/createclaim", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return apiResp, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.client.AuthToken))
-
-	resp, err := s.client.HTTPClient.Do(req)
-	if err != nil {
-		return apiResp, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return apiResp, fmt.Errorf("failed to read response body: %w", err)
+	request := apiRequest{
+		operation:   "CreateClaim",
+		method:      http.MethodPost,
+		url:         s.client.BaseUrl + "/createclaim",
+		body:        jsonData,
+		contentType: "application/json",
+		payload:     claim,
 	}
 
-	if err := checkResponse(resp); err != nil {
+	if err := s.client.doJSON(ctx, request, &apiResp); err != nil {
 		return apiResp, err
 	}
 
-	if err := json.Unmarshal(bodyBytes, &apiResp); err != nil {
-		return apiResp, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return apiResp, nil
 }
 
 func (s *ClaimsService) UpdateClaim(ctx context.Context, claim ClaimPost) (ApiResponse, error) {
 	var apiResp ApiResponse
 
-	jsonData, err := json.Marshal(claim)
-	if err != nil {
-		return apiResp, fmt.Errorf("failed to marshal claim data: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.client.BaseUrl+"/updateclaim", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return apiResp, fmt.Errorf("failed to create request: %w", err)
+	if err := claim.ValidateForUpdate(s.client.validationOptions()...); err != nil {
+		return apiResp, fmt.Errorf("claim validation failed: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.client.AuthToken))
-
-	resp, err := s.client.HTTPClient.Do(req)
+	jsonData, err := json.Marshal(claim)
 	if err != nil {
-		return apiResp, fmt.Errorf("request failed: %w", err)
+		return apiResp, fmt.Errorf("failed to marshal claim data: %w", err)
 	}
-	defer resp.Body.Close()
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return apiResp, fmt.Errorf("failed to read response body: %w", err)
+	request := apiRequest{
+		operation:   "UpdateClaim",
+		method:      http.MethodPost,
+		url:         s.client.BaseUrl + "/updateclaim",
+		body:        jsonData,
+		contentType: "application/json",
+		filenumber:  claim.FileNumber,
+		payload:     claim,
 	}
 
-	if err := checkResponse(resp); err != nil {
+	if err := s.client.doJSON(ctx, request, &apiResp); err != nil {
 		return apiResp, err
 	}
 
-	if err := json.Unmarshal(bodyBytes, &apiResp); err != nil {
-		return apiResp, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return apiResp, nil
 }
 
 func (s *ClaimsService) GetSingleClaim(ctx context.Context, filenumber int) (Claim, error) {
 	var claims []Claim
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.client.BaseUrl+fmt.Sprintf("/getclaims/%d", filenumber), nil)
-	if err != nil {
-		return Claim{}, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.client.AuthToken))
-
-	resp, err := s.client.HTTPClient.Do(req)
-	if err != nil {
-		return Claim{}, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return Claim{}, fmt.Errorf("failed to read response body: %w", err)
+	request := apiRequest{
+		operation:  "GetSingleClaim",
+		method:     http.MethodGet,
+		url:        s.client.BaseUrl + fmt.Sprintf("/getclaims/%d", filenumber),
+		filenumber: filenumber,
 	}
 
-	if err := checkResponse(resp); err != nil {
+	if err := s.client.doJSON(ctx, request, &claims); err != nil {
 		return Claim{}, err
 	}
 
-	if err := json.Unmarshal(bodyBytes, &claims); err != nil {
-		return Claim{}, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	if len(claims) == 0 {
-		return Claim{}, fmt.Errorf("no claim found with filenumber %d", filenumber)
+		return Claim{}, request.notFound("no claim found with filenumber %d", filenumber)
 	}
 
 	return claims[0], nil
@@ -215,34 +173,21 @@ func (s *ClaimsService) GetClaims(ctx context.Context, opts ...GetClaimsOption)
 	for _, opt := range opts {
 		opt(&options)
 	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.client.BaseUrl+fmt.Sprintf("/getclaims/all/%t", options.includeInactive), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.client.AuthToken))
-
-	resp, err := s.client.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+	if options.pageSize > 0 {
+		return collect(s.IterateClaims(ctx, opts...))
 	}
-	defer resp.Body.Close()
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	var claims []Claim
+	request := apiRequest{
+		operation: "GetClaims",
+		method:    http.MethodGet,
+		url:       s.client.BaseUrl + fmt.Sprintf("/getclaims/all/%t", options.includeInactive),
 	}
 
-	if err := checkResponse(resp); err != nil {
+	if err := s.client.doJSON(ctx, request, &claims); err != nil {
 		return nil, err
 	}
 
-	var claims []Claim
-	if err := json.Unmarshal(bodyBytes, &claims); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return claims, nil
 }
 
@@ -254,6 +199,7 @@ type getAdminClaimsOptions struct {
 	includeInactive bool
 	docfiles        bool
 	logtrail        bool
+	pageSize        int
 }
 
 func WithFilenumber(filenumber *int) GetAdminClaimsOption {
@@ -280,12 +226,32 @@ func WithLogTrail(include bool) GetAdminClaimsOption {
 	}
 }
 
+// WithAdminPageSize requests admin claims from the server in pages of size
+// claims.
+func WithAdminPageSize(size int) GetAdminClaimsOption {
+	return func(opts *getAdminClaimsOptions) {
+		opts.pageSize = size
+	}
+}
+
 func (s *ClaimsService) GetAdminClaims(ctx context.Context, opts ...GetAdminClaimsOption) ([]AdminClaim, error) {
 	options := getAdminClaimsOptions{includeInactive: false, docfiles: false, logtrail: false}
 	for _, opt := range opts {
 		opt(&options)
 	}
+	if options.pageSize > 0 {
+		return collect(s.IterateAdminClaims(ctx, opts...))
+	}
+
+	var claims []AdminClaim
+	if err := s.client.doJSON(ctx, s.adminClaimsRequest(options), &claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
 
+func (s *ClaimsService) adminClaimsRequest(options getAdminClaimsOptions) apiRequest {
 	u, _ := url.Parse(s.client.BaseUrl + "/getadminclaims")
 	queryParams := url.Values{}
 	if options.filenumber != nil {
@@ -296,32 +262,14 @@ func (s *ClaimsService) GetAdminClaims(ctx context.Context, opts ...GetAdminClai
 	queryParams.Add("logtrail", fmt.Sprintf("%t", options.logtrail))
 	u.RawQuery = queryParams.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.client.AuthToken))
-
-	resp, err := s.client.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if err := checkResponse(resp); err != nil {
-		return nil, err
+	request := apiRequest{
+		operation: "GetAdminClaims",
+		method:    http.MethodGet,
+		url:       u.String(),
 	}
-
-	var claims []AdminClaim
-	if err := json.Unmarshal(bodyBytes, &claims); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if options.filenumber != nil {
+		request.filenumber = *options.filenumber
 	}
 
-	return claims, nil
+	return request
 }
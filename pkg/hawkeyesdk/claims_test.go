@@ -35,7 +35,7 @@ func TestClaimsService_CreateClaim_Success(t *testing.T) {
 		if body.InsCompaniesID != "Hawkeye" {
 			t.Fatalf("unexpected insurance company: %s", body.InsCompaniesID)
 		}
-		if body.VehVIN != "VIN123" {
+		if body.VehVIN != "1HGCM82633A004352" {
 			t.Fatalf("unexpected VIN: %s", body.VehVIN)
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -58,7 +58,7 @@ func TestClaimsService_CreateClaim_Success(t *testing.T) {
 		VehMake:        "Ford",
 		VehModel:       "F150",
 		VehColor:       "Blue",
-		VehVIN:         "VIN123",
+		VehVIN:         "1HGCM82633A004352",
 	})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
@@ -92,7 +92,7 @@ func TestClaimsService_CreateClaim_HTTPError(t *testing.T) {
 		VehMake:        "Ford",
 		VehModel:       "F150",
 		VehColor:       "Blue",
-		VehVIN:         "VIN123",
+		VehVIN:         "1HGCM82633A004352",
 	})
 	if err == nil {
 		t.Fatalf("expected error, got nil")
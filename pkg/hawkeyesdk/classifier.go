@@ -0,0 +1,200 @@
+package hawkeyesdk
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Classification is a proposed DocType for a file. Confidence ranges from 0
+// (no rule matched, DocType is DEFAULT) to 1.
+type Classification struct {
+	DocType     DocType
+	Confidence  float64
+	ContentType string
+	Rule        string
+}
+
+// ClassificationRule proposes DocType when every condition that is set
+// matches. Keywords are matched against the words of the file name, so
+// "checkin", "check-in" and "Check_In" all match the keyword "check in".
+type ClassificationRule struct {
+	Name       string
+	DocType    DocType
+	Confidence float64
+
+	Keywords     []string
+	Extensions   []string
+	MIMEPrefixes []string
+
+	// Match, when set, must also return true for the rule to apply.
+	Match func(filename, contentType string) bool
+}
+
+func (r ClassificationRule) matches(name classifierName, ext, contentType, filename string) bool {
+	if len(r.Keywords) > 0 && !slices.ContainsFunc(r.Keywords, name.contains) {
+		return false
+	}
+	if len(r.Extensions) > 0 && !slices.ContainsFunc(r.Extensions, func(e string) bool {
+		return strings.EqualFold(strings.TrimPrefix(e, "."), ext)
+	}) {
+		return false
+	}
+	if len(r.MIMEPrefixes) > 0 && !slices.ContainsFunc(r.MIMEPrefixes, func(p string) bool {
+		return strings.HasPrefix(contentType, p)
+	}) {
+		return false
+	}
+	if r.Match != nil && !r.Match(filename, contentType) {
+		return false
+	}
+	return true
+}
+
+// classifierName holds a file name normalized for keyword matching.
+type classifierName struct {
+	padded   string
+	squashed string
+}
+
+func newClassifierName(filename string) classifierName {
+	base := strings.TrimSuffix(filename, filepath.Ext(filename))
+	words := normalizeDocTypeName(base)
+	return classifierName{
+		padded:   " " + words + " ",
+		squashed: strings.ReplaceAll(words, " ", ""),
+	}
+}
+
+func (n classifierName) contains(keyword string) bool {
+	keyword = normalizeDocTypeName(keyword)
+	if strings.Contains(n.padded, " "+keyword+" ") {
+		return true
+	}
+	return strings.Contains(keyword, " ") && strings.Contains(n.squashed, strings.ReplaceAll(keyword, " ", ""))
+}
+
+var videoExtensions = []string{"mp4", "mov", "m4v", "avi", "wmv", "webm", "3gp", "mkv"}
+
+func defaultClassificationRules() []ClassificationRule {
+	return []ClassificationRule{
+		{Name: "check-in video", DocType: CHECK_IN_VIDEO, Confidence: 0.9, Keywords: []string{"check in", "drop off", "dropoff"}, Extensions: videoExtensions},
+		{Name: "check-out video", DocType: CHECK_OUT_VIDEO, Confidence: 0.9, Keywords: []string{"check out", "pick up", "pickup"}, Extensions: videoExtensions},
+		{Name: "recorded statement", DocType: RECORDED_STATEMENT, Confidence: 0.85, Keywords: []string{"statement"}, MIMEPrefixes: []string{"audio/"}},
+		{Name: "call recording", DocType: CALL_RECORDING, Confidence: 0.85, Keywords: []string{"call", "recording", "voicemail"}, MIMEPrefixes: []string{"audio/"}},
+		{Name: "email", DocType: EMAIL, Confidence: 0.9, Extensions: []string{"eml", "msg"}},
+		{Name: "police report", DocType: POLICE_REPORT, Confidence: 0.85, Keywords: []string{"police", "police report", "crash report"}},
+		{Name: "aob", DocType: AOB, Confidence: 0.85, Keywords: []string{"aob", "assignment of benefits"}},
+		{Name: "poa", DocType: POA, Confidence: 0.85, Keywords: []string{"poa", "power of attorney"}},
+		{Name: "vehicle history", DocType: VEHICLE_HISTORY, Confidence: 0.85, Keywords: []string{"carfax", "autocheck", "vehicle history"}},
+		{Name: "rental agreement", DocType: RENTAL_AGREEMENT, Confidence: 0.8, Keywords: []string{"rental agreement", "rental contract", "ra"}},
+		{Name: "insurance card", DocType: INSURANCE_CARD, Confidence: 0.8, Keywords: []string{"insurance card", "id card", "proof of insurance"}},
+		{Name: "tow bill", DocType: TOW_BILL, Confidence: 0.8, Keywords: []string{"tow", "towing", "tow bill"}},
+		{Name: "interim invoice", DocType: INTERIM_INVOICE, Confidence: 0.8, Keywords: []string{"interim invoice"}},
+		{Name: "final invoice", DocType: FINAL_INVOICE, Confidence: 0.8, Keywords: []string{"final invoice"}},
+		{Name: "vendor invoice", DocType: VENDOR_INVOICE, Confidence: 0.75, Keywords: []string{"vendor invoice", "vendor inv"}},
+		{Name: "denial letter", DocType: DENIAL_LETTER, Confidence: 0.8, Keywords: []string{"denial", "denied"}},
+		{Name: "settlement check", DocType: SETTLEMENT_CHECK, Confidence: 0.8, Keywords: []string{"settlement check"}},
+		{Name: "registration", DocType: REGISTRATION, Confidence: 0.8, Keywords: []string{"registration"}},
+		{Name: "bill of lading", DocType: BILL_OF_LADING, Confidence: 0.8, Keywords: []string{"bill of lading", "bol"}},
+		{Name: "drivers license", DocType: DRIVERS_LICENSE, Confidence: 0.75, Keywords: []string{"drivers license", "driver license", "license", "licence", "dl"}},
+		{Name: "market valuation", DocType: MARKET_VALUATION, Confidence: 0.75, Keywords: []string{"valuation", "market value", "ccc"}},
+		{Name: "dv form", DocType: DV_FORM, Confidence: 0.75, Keywords: []string{"dv form", "diminished value"}},
+		{Name: "demand letter", DocType: DEMAND_LETTER, Confidence: 0.7, Keywords: []string{"demand", "demand letter"}},
+		{Name: "incident report", DocType: INCIDENT_REPORT, Confidence: 0.7, Keywords: []string{"incident"}},
+		{Name: "policy", DocType: POLICY, Confidence: 0.7, Keywords: []string{"policy", "declarations", "dec page"}},
+		{Name: "release", DocType: RELEASE, Confidence: 0.7, Keywords: []string{"release"}},
+		{Name: "damage assessment", DocType: DAMAGE_ASSESSMENT, Confidence: 0.6, Keywords: []string{"estimate", "appraisal", "damage"}},
+		{Name: "invoice", DocType: INVOICE, Confidence: 0.6, Keywords: []string{"invoice", "inv"}},
+		{Name: "title", DocType: TITLE, Confidence: 0.6, Keywords: []string{"title"}},
+		{Name: "receipt", DocType: EXPENSE_RECEIPT, Confidence: 0.6, Keywords: []string{"receipt"}},
+		{Name: "images", DocType: IMAGES, Confidence: 0.6, MIMEPrefixes: []string{"image/"}},
+		{Name: "audio", DocType: CALL_RECORDING, Confidence: 0.5, MIMEPrefixes: []string{"audio/"}},
+	}
+}
+
+// DocTypeClassifier proposes a DocType from a file name and content. User
+// rules added with AddRule are considered before the built-in ones; among
+// matching rules the highest confidence wins. It is safe for concurrent use.
+type DocTypeClassifier struct {
+	mu    sync.RWMutex
+	rules []ClassificationRule
+}
+
+// NewDocTypeClassifier returns a classifier using the built-in rules.
+func NewDocTypeClassifier() *DocTypeClassifier {
+	return &DocTypeClassifier{rules: defaultClassificationRules()}
+}
+
+// AddRule registers a user rule that takes precedence over the built-in
+// rules when confidences tie.
+func (c *DocTypeClassifier) AddRule(rule ClassificationRule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rules = append([]ClassificationRule{rule}, c.rules...)
+}
+
+// Classify proposes a DocType for filename. contentType may be empty, in
+// which case it is sniffed from head (the first bytes of the file) or derived
+// from the extension.
+func (c *DocTypeClassifier) Classify(filename, contentType string, head []byte) Classification {
+	if contentType == "" && len(head) > 0 {
+		contentType = http.DetectContentType(head)
+	}
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+	if contentType == "" || contentType == "application/octet-stream" {
+		if byExt := mime.TypeByExtension("." + ext); byExt != "" {
+			contentType = byExt
+		}
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+
+	name := newClassifierName(filename)
+	result := Classification{DocType: DEFAULT, ContentType: contentType}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, rule := range c.rules {
+		if rule.Confidence > result.Confidence && rule.matches(name, ext, contentType, filename) {
+			result.DocType = rule.DocType
+			result.Confidence = rule.Confidence
+			result.Rule = rule.Name
+		}
+	}
+
+	return result
+}
+
+// WithAutoCategory picks the upload category with classifier when no
+// explicit WithCategory is given and the proposal reaches minConfidence.
+func WithAutoCategory(classifier *DocTypeClassifier, minConfidence float64) UploadFileOption {
+	return func(opts *uploadFileOptions) {
+		opts.classifier = classifier
+		opts.minConfidence = minConfidence
+	}
+}
+
+// autoCategorize applies the classifier configured through WithAutoCategory.
+func (o *uploadFileOptions) autoCategorize(filename, contentType string, head []byte) {
+	if o.classifier == nil || o.categorySet {
+		return
+	}
+	if proposal := o.classifier.Classify(filename, contentType, head); proposal.Confidence >= o.minConfidence && proposal.Confidence > 0 {
+		o.category = proposal.DocType
+	}
+}
+
+// filenameFromURL returns the last path element of a document link.
+func filenameFromURL(rawURL string) string {
+	if i := strings.IndexAny(rawURL, "?#"); i >= 0 {
+		rawURL = rawURL[:i]
+	}
+	return path.Base(rawURL)
+}
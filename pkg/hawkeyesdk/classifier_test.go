@@ -0,0 +1,142 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDocTypeClassifier_Classify(t *testing.T) {
+	t.Parallel()
+
+	classifier := NewDocTypeClassifier()
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	cases := []struct {
+		filename    string
+		contentType string
+		head        []byte
+		expected    DocType
+	}{
+		{"Unit42_CheckIn.mp4", "", nil, CHECK_IN_VIDEO},
+		{"unit42-check-out.MOV", "", nil, CHECK_OUT_VIDEO},
+		{"checkin.pdf", "", nil, DEFAULT},
+		{"police_report_2024.pdf", "", nil, POLICE_REPORT},
+		{"Signed AOB.pdf", "", nil, AOB},
+		{"tow bill 123.pdf", "", nil, TOW_BILL},
+		{"Final-Invoice.pdf", "", nil, FINAL_INVOICE},
+		{"invoice.pdf", "", nil, INVOICE},
+		{"customer call.mp3", "audio/mpeg", nil, CALL_RECORDING},
+		{"thread.eml", "", nil, EMAIL},
+		{"IMG_0001", "", png, IMAGES},
+		{"notes.txt", "", []byte("hello"), DEFAULT},
+	}
+	for _, tc := range cases {
+		got := classifier.Classify(tc.filename, tc.contentType, tc.head)
+		if got.DocType != tc.expected {
+			t.Fatalf("%q classified as %v (rule %q), expected %v", tc.filename, got.DocType, got.Rule, tc.expected)
+		}
+		if tc.expected == DEFAULT && got.Confidence != 0 {
+			t.Fatalf("%q: expected zero confidence for DEFAULT, got %v", tc.filename, got.Confidence)
+		}
+		if tc.expected != DEFAULT && (got.Confidence <= 0 || got.Confidence > 1) {
+			t.Fatalf("%q: unexpected confidence %v", tc.filename, got.Confidence)
+		}
+	}
+
+	if got := classifier.Classify("IMG_0001", "", png); got.ContentType != "image/png" {
+		t.Fatalf("expected sniffed content type image/png, got %q", got.ContentType)
+	}
+}
+
+func TestDocTypeClassifier_UserRules(t *testing.T) {
+	t.Parallel()
+
+	classifier := NewDocTypeClassifier()
+	classifier.AddRule(ClassificationRule{
+		Name:       "adjuster photos",
+		DocType:    DAMAGE_ASSESSMENT,
+		Confidence: 0.6,
+		Match: func(filename, contentType string) bool {
+			return strings.HasPrefix(filename, "ADJ-") && strings.HasPrefix(contentType, "image/")
+		},
+	})
+	classifier.AddRule(ClassificationRule{
+		Name:       "lien letters",
+		DocType:    LIENHOLDER_INFO,
+		Confidence: 0.95,
+		Keywords:   []string{"lien"},
+	})
+
+	got := classifier.Classify("ADJ-001.png", "image/png", nil)
+	if got.DocType != DAMAGE_ASSESSMENT || got.Rule != "adjuster photos" {
+		t.Fatalf("expected user rule to win the tie with IMAGES, got %+v", got)
+	}
+	if got := classifier.Classify("bank lien release.pdf", "", nil); got.DocType != LIENHOLDER_INFO {
+		t.Fatalf("expected higher confidence user rule to win, got %+v", got)
+	}
+	if got := classifier.Classify("IMG_1.png", "image/png", nil); got.DocType != IMAGES {
+		t.Fatalf("expected built-in rule for unrelated file, got %+v", got)
+	}
+}
+
+func TestDocFilesService_UploadBytes_AutoCategory(t *testing.T) {
+	t.Parallel()
+
+	categories := make(chan string, 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Errorf("bad content type: %v", err)
+			return
+		}
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				break
+			}
+			if part.FormName() == "category" {
+				value, _ := io.ReadAll(part)
+				categories <- string(value)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:      "token",
+		BaseUrl:        server.URL,
+		HTTPClient:     server.Client(),
+		UploadEndpoint: "/uploadfile",
+	}
+	service := NewDocFilesService(client)
+	classifier := NewDocTypeClassifier()
+
+	if _, err := service.UploadBytes(context.Background(), 1, "police_report.pdf", "", []byte("%PDF-1.4"), WithAutoCategory(classifier, 0.5)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := <-categories; got != POLICE_REPORT.String() {
+		t.Fatalf("expected auto category %q, got %q", POLICE_REPORT.String(), got)
+	}
+
+	if _, err := service.UploadBytes(context.Background(), 1, "police_report.pdf", "", []byte("%PDF-1.4"), WithAutoCategory(classifier, 0.9)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := <-categories; got != DEFAULT.String() {
+		t.Fatalf("expected low confidence proposal to be ignored, got %q", got)
+	}
+
+	if _, err := service.UploadBytes(context.Background(), 1, "police_report.pdf", "", []byte("%PDF-1.4"), WithCategory(OTHER), WithAutoCategory(classifier, 0)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := <-categories; got != OTHER.String() {
+		t.Fatalf("expected explicit category to win, got %q", got)
+	}
+}
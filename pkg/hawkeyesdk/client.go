@@ -1,6 +1,10 @@
 package hawkeyesdk
 
-import "net/http"
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
 
 type ClientSettings struct {
 	AuthToken  string
@@ -8,6 +12,50 @@ type ClientSettings struct {
 	BaseUrl    string
 	HTTPClient *http.Client
 
+	// TokenSource, when set, is asked for the token on every request in place
+	// of AuthToken, see WithTokenSource.
+	TokenSource TokenSource
+
+	// Timeout bounds how long each attempt waits for its response once the
+	// request has been sent, see WithTimeout.
+	Timeout time.Duration
+
+	// RetryPolicy applies to every service call. NewHawkeyeClient starts from
+	// DefaultRetryPolicy; a zero value disables retries.
+	RetryPolicy RetryPolicy
+
+	// RateLimiter throttles every service call when set. 429 responses pause
+	// it until the server's Retry-After has elapsed.
+	RateLimiter *RateLimiter
+	inFlight    chan struct{}
+
+	// Middlewares wrap every HTTP attempt, see WithMiddleware.
+	Middlewares []Middleware
+
+	// Logger receives one record per HTTP attempt, see WithLogger.
+	Logger *slog.Logger
+	// RedactedHeaders are redacted from logged headers in addition to the
+	// built-in ones, see WithRedactedHeaders.
+	RedactedHeaders []string
+
+	// UploadEndpoint is the path, relative to BaseUrl, that accepts multipart
+	// uploads, see WithUploadEndpoint.
+	UploadEndpoint string
+	// DownloadEndpoint is the path that serves document content, see
+	// WithDownloadEndpoint.
+	DownloadEndpoint string
+	// LogTrailEndpoint is the path that lists a claim's log trail, see
+	// WithLogTrailEndpoint.
+	LogTrailEndpoint string
+
+	// StrictDocTypes makes responses with unknown document types fail to
+	// decode, see WithStrictDocTypes.
+	StrictDocTypes bool
+
+	// VINCheckDigit makes claim validation check the VIN check digit, see
+	// WithVINCheckDigit.
+	VINCheckDigit bool
+
 	// Services
 	Claims       *ClaimsService
 	DocFiles     *DocFilesService
@@ -24,22 +72,94 @@ const (
 	DEV  Environment = "dev"
 )
 
+const (
+	prodBaseURL = "https://hawkeye.g2it.co/api"
+	devBaseURL  = "https://qa.hawkeye.g2it.co/api"
+)
+
 func WithEnvironment(env Environment) Option {
 	return func(c *ClientSettings) {
 		if env == DEV {
 			c.DevEnv = true
-			c.BaseUrl = "https://qa.hawkeye.g2it.co/api"
+			c.BaseUrl = devBaseURL
 		} else {
 			c.DevEnv = false
-			c.BaseUrl = "https://hawkeye.g2it.co/api"
+			c.BaseUrl = prodBaseURL
 		}
 	}
 }
 
+// WithTimeout fails an attempt whose response headers have not arrived d
+// after the request, including any upload body, was sent. Reading the
+// response is not bounded, so long downloads and iterators are not cut off;
+// use the context for an overall deadline. A timed-out attempt is retried
+// according to the RetryPolicy and its error wraps os.ErrDeadlineExceeded.
+func WithTimeout(d time.Duration) Option {
+	return func(c *ClientSettings) {
+		c.Timeout = d
+	}
+}
+
+// WithUploadEndpoint sets the path that UploadReader, UploadBytes and
+// UploadPath post multipart content to, such as "/uploadfile". The published
+// API only accepts links, through UploadFile, so these helpers fail with
+// ErrConfig until the endpoint your deployment exposes is configured.
+func WithUploadEndpoint(path string) Option {
+	return func(c *ClientSettings) {
+		c.UploadEndpoint = path
+	}
+}
+
+// WithDownloadEndpoint sets the path that DownloadDocFile and SaveDocFile
+// read documents from, as path/{filenumber}/{filename}. The published API
+// does not serve document content, so they fail with ErrConfig until the
+// endpoint your deployment exposes is configured.
+func WithDownloadEndpoint(path string) Option {
+	return func(c *ClientSettings) {
+		c.DownloadEndpoint = path
+	}
+}
+
+// WithLogTrailEndpoint sets the path that ListLogTrails reads entries from,
+// as path/{filenumber}, such as "/getlogtrail". The published API has no
+// log trail listing, so ListLogTrails fails with ErrConfig until the endpoint
+// your deployment exposes is configured.
+func WithLogTrailEndpoint(path string) Option {
+	return func(c *ClientSettings) {
+		c.LogTrailEndpoint = path
+	}
+}
+
+// WithStrictDocTypes makes responses that contain a document type this
+// version of the SDK does not know fail with a *DecodeError, see
+// CheckDocTypes. By default such types decode as DEFAULT.
+func WithStrictDocTypes() Option {
+	return func(c *ClientSettings) {
+		c.StrictDocTypes = true
+	}
+}
+
+// WithVINCheckDigit makes CreateClaim, UpdateClaim and ClaimImporter reject
+// VINs that are not 17 characters or whose North American check digit does
+// not match, see RequireVINCheckDigit.
+func WithVINCheckDigit() Option {
+	return func(c *ClientSettings) {
+		c.VINCheckDigit = true
+	}
+}
+
+func (c *ClientSettings) validationOptions() []ValidationOption {
+	if c.VINCheckDigit {
+		return []ValidationOption{RequireVINCheckDigit()}
+	}
+	return nil
+}
+
 func NewHawkeyeClient(authToken string, opts ...Option) *ClientSettings {
 	client := ClientSettings{
-		AuthToken: authToken,
-		BaseUrl:   "https://hawkeye.g2it.co/api",
+		AuthToken:   authToken,
+		BaseUrl:     prodBaseURL,
+		RetryPolicy: DefaultRetryPolicy(),
 	}
 
 	for _, opt := range opts {
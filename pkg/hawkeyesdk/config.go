@@ -0,0 +1,446 @@
+package hawkeyesdk
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds client settings loaded from a config file and the
+// environment. Build a client from it with NewHawkeyeClientFromConfig.
+type Config struct {
+	// Profile is the config file section the settings were read from.
+	Profile string
+
+	// Token is the API token. TokenFile names a file holding the token
+	// instead; it is reread when it changes. Setting one clears the other.
+	Token     string
+	TokenFile string
+
+	// Environment selects the default base URL; BaseURL overrides it, for
+	// example for a sandbox.
+	Environment Environment
+	BaseURL     string
+
+	// Timeout bounds each HTTP attempt; zero means no timeout.
+	Timeout time.Duration
+
+	// RetryAttempts replaces DefaultRetryPolicy's MaxAttempts when set; 1
+	// disables retries.
+	RetryAttempts int
+
+	// RateLimit, RateBurst and MaxConcurrency apply WithRateLimit and
+	// WithMaxConcurrency when set.
+	RateLimit      float64
+	RateBurst      int
+	MaxConcurrency int
+
+	// UploadEndpoint, DownloadEndpoint and LogTrailEndpoint apply
+	// WithUploadEndpoint, WithDownloadEndpoint and WithLogTrailEndpoint when
+	// set.
+	UploadEndpoint   string
+	DownloadEndpoint string
+	LogTrailEndpoint string
+}
+
+// DefaultProfile is used when no profile is selected.
+const DefaultProfile = "default"
+
+// Environment variables read by LoadConfig. Every config file key can also be
+// set as HAWKEYE_ followed by the key in upper case, e.g. HAWKEYE_BASE_URL.
+const (
+	EnvConfigFile = "HAWKEYE_CONFIG"
+	EnvProfile    = "HAWKEYE_PROFILE"
+	envPrefix     = "HAWKEYE_"
+)
+
+// configKeys lists the keys accepted in config files, in the order their
+// environment variables are applied.
+var configKeys = []string{
+	"token", "token_file", "environment", "base_url", "timeout",
+	"retry_attempts", "rate_limit", "rate_burst", "max_concurrency",
+	"upload_endpoint", "download_endpoint", "logtrail_endpoint",
+}
+
+type ConfigOption func(*configOptions)
+
+type configOptions struct {
+	profile     string
+	file        string
+	fileSet     bool
+	lookupEnv   func(string) (string, bool)
+	defaultFile func() (string, error)
+
+	// tokenOptional skips the token check when the client gets a
+	// TokenSource from its options instead.
+	tokenOptional bool
+}
+
+// WithProfile selects the config file section to load, overriding
+// HAWKEYE_PROFILE.
+func WithProfile(name string) ConfigOption {
+	return func(o *configOptions) {
+		o.profile = name
+	}
+}
+
+// WithConfigFile reads the config from path, overriding HAWKEYE_CONFIG and
+// the default location. An empty path disables the config file.
+func WithConfigFile(path string) ConfigOption {
+	return func(o *configOptions) {
+		o.file = path
+		o.fileSet = true
+	}
+}
+
+// WithConfigEnv replaces os.LookupEnv, for tests or to ignore the process
+// environment.
+func WithConfigEnv(lookup func(string) (string, bool)) ConfigOption {
+	return func(o *configOptions) {
+		o.lookupEnv = lookup
+	}
+}
+
+// DefaultConfigFile returns the config file read when neither
+// WithConfigFile nor HAWKEYE_CONFIG is set: hawkeye/config under the user's
+// configuration directory.
+func DefaultConfigFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "hawkeye", "config"), nil
+}
+
+// LoadConfig builds a Config from, in increasing priority: the top-level keys
+// of the config file, the selected profile's section, and HAWKEYE_*
+// environment variables. The config file is optional unless named
+// explicitly; a profile other than the default must exist in it, except
+// "prod", "qa" and "dev", which default their environment accordingly.
+//
+// A config file looks like:
+//
+//	# shared by every profile
+//	timeout = 30s
+//
+//	[prod]
+//	token_file = /run/secrets/hawkeye
+//
+//	[sandbox]
+//	base_url = https://sandbox.example.com/api
+//	token = "abc123"
+func LoadConfig(opts ...ConfigOption) (*Config, error) {
+	options := configOptions{lookupEnv: os.LookupEnv, defaultFile: DefaultConfigFile}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	profile := options.profile
+	if profile == "" {
+		profile, _ = options.lookupEnv(EnvProfile)
+	}
+	explicitProfile := strings.TrimSpace(profile) != ""
+	if !explicitProfile {
+		profile = DefaultProfile
+	}
+	profile = strings.TrimSpace(profile)
+
+	path, required := options.file, options.fileSet
+	if !required {
+		if env, ok := options.lookupEnv(EnvConfigFile); ok && env != "" {
+			path, required = env, true
+		} else if def, err := options.defaultFile(); err == nil {
+			path = def
+		}
+	}
+
+	cfg := &Config{Profile: profile}
+	found := false
+	if path != "" {
+		file, err := os.Open(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && !required:
+		case err != nil:
+			return nil, newSentinelError(ErrConfig, "failed to read config file: %v", err)
+		default:
+			defer file.Close()
+			sections, err := parseConfigFile(file, path)
+			if err != nil {
+				return nil, err
+			}
+			if global, ok := sections[""]; ok {
+				if err := cfg.apply(global); err != nil {
+					return nil, err
+				}
+			}
+			if section, ok := sections[profile]; ok {
+				found = true
+				if err := cfg.apply(section); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if !found && explicitProfile && profile != DefaultProfile {
+		if _, builtin := builtinProfileEnvironment(profile); !builtin {
+			return nil, newSentinelError(ErrConfig, "hawkeye config: profile %q not found", profile)
+		}
+	}
+
+	for _, key := range configKeys {
+		name := envPrefix + strings.ToUpper(key)
+		value, ok := options.lookupEnv(name)
+		if !ok && key == "environment" {
+			name = envPrefix + "ENV"
+			value, ok = options.lookupEnv(name)
+		}
+		if !ok || value == "" {
+			continue
+		}
+		if err := cfg.set(key, value); err != nil {
+			return nil, newSentinelError(ErrConfig, "hawkeye config: %s: %v", name, err)
+		}
+	}
+
+	if cfg.Environment == "" {
+		cfg.Environment, _ = builtinProfileEnvironment(profile)
+	}
+
+	if err := cfg.validate(!options.tokenOptional); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func builtinProfileEnvironment(profile string) (Environment, bool) {
+	switch strings.ToLower(profile) {
+	case "prod", "production":
+		return PROD, true
+	case "qa", "dev":
+		return DEV, true
+	}
+	return "", false
+}
+
+type configEntry struct {
+	key, value string
+	source     string
+}
+
+// parseConfigFile splits a config file into sections; keys before the first
+// section are stored under "".
+func parseConfigFile(r io.Reader, name string) (map[string][]configEntry, error) {
+	sections := map[string][]configEntry{}
+	section := ""
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		source := fmt.Sprintf("%s:%d", name, line)
+		switch {
+		case text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";"):
+			continue
+		case strings.HasPrefix(text, "["):
+			if !strings.HasSuffix(text, "]") {
+				return nil, newSentinelError(ErrConfig, "%s: unterminated section header", source)
+			}
+			section = strings.TrimSpace(text[1 : len(text)-1])
+			if section == "" {
+				return nil, newSentinelError(ErrConfig, "%s: empty section name", source)
+			}
+			if _, dup := sections[section]; dup {
+				return nil, newSentinelError(ErrConfig, "%s: duplicate section [%s]", source, section)
+			}
+			sections[section] = nil
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, newSentinelError(ErrConfig, "%s: expected key = value", source)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, newSentinelError(ErrConfig, "%s: invalid quoted value: %v", source, err)
+			}
+			value = unquoted
+		}
+		sections[section] = append(sections[section], configEntry{key: key, value: value, source: source})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, newSentinelError(ErrConfig, "failed to read config file: %v", err)
+	}
+	return sections, nil
+}
+
+func (c *Config) apply(entries []configEntry) error {
+	for _, e := range entries {
+		if err := c.set(e.key, e.value); err != nil {
+			return newSentinelError(ErrConfig, "%s: %s: %v", e.source, e.key, err)
+		}
+	}
+	return nil
+}
+
+func (c *Config) set(key, value string) error {
+	var err error
+	switch key {
+	case "token":
+		c.Token, c.TokenFile = value, ""
+	case "token_file":
+		c.TokenFile, c.Token = value, ""
+	case "environment":
+		c.Environment, err = ParseEnvironment(value)
+	case "base_url":
+		c.BaseURL = value
+	case "timeout":
+		c.Timeout, err = time.ParseDuration(value)
+	case "retry_attempts":
+		c.RetryAttempts, err = strconv.Atoi(value)
+	case "rate_limit":
+		c.RateLimit, err = strconv.ParseFloat(value, 64)
+	case "rate_burst":
+		c.RateBurst, err = strconv.Atoi(value)
+	case "max_concurrency":
+		c.MaxConcurrency, err = strconv.Atoi(value)
+	case "upload_endpoint":
+		c.UploadEndpoint = value
+	case "download_endpoint":
+		c.DownloadEndpoint = value
+	case "logtrail_endpoint":
+		c.LogTrailEndpoint = value
+	default:
+		return fmt.Errorf("unknown key")
+	}
+	return err
+}
+
+// ParseEnvironment accepts "prod"/"production" and "dev"/"qa", ignoring
+// case.
+func ParseEnvironment(s string) (Environment, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "prod", "production":
+		return PROD, nil
+	case "dev", "qa":
+		return DEV, nil
+	}
+	return "", fmt.Errorf("unknown environment %q", s)
+}
+
+// Validate reports every problem with c at once.
+func (c *Config) Validate() error {
+	return c.validate(true)
+}
+
+func (c *Config) validate(requireToken bool) error {
+	var problems []string
+	if requireToken && c.Token == "" && c.TokenFile == "" {
+		problems = append(problems, "no token: set token or token_file, or HAWKEYE_TOKEN")
+	}
+	if c.Environment != "" && c.Environment != PROD && c.Environment != DEV {
+		problems = append(problems, fmt.Sprintf("unknown environment %q", c.Environment))
+	}
+	if c.BaseURL != "" {
+		u, err := url.Parse(c.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("base_url %q is not an http(s) URL", c.BaseURL))
+		}
+	}
+	if c.Timeout < 0 {
+		problems = append(problems, "timeout must not be negative")
+	}
+	if c.RetryAttempts < 0 {
+		problems = append(problems, "retry_attempts must not be negative")
+	}
+	if c.RateLimit < 0 || c.RateBurst < 0 {
+		problems = append(problems, "rate_limit and rate_burst must not be negative")
+	}
+	if c.MaxConcurrency < 0 {
+		problems = append(problems, "max_concurrency must not be negative")
+	}
+	if len(problems) > 0 {
+		return newSentinelError(ErrConfig, "hawkeye config (profile %s): %s", c.Profile, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Options returns the client options equivalent to c.
+func (c *Config) Options() []Option {
+	var opts []Option
+	if c.Environment != "" {
+		opts = append(opts, WithEnvironment(c.Environment))
+	}
+	if c.BaseURL != "" {
+		baseURL := strings.TrimRight(c.BaseURL, "/")
+		opts = append(opts, func(s *ClientSettings) { s.BaseUrl = baseURL })
+	}
+	if c.TokenFile != "" {
+		opts = append(opts, WithTokenSource(NewFileTokenSource(c.TokenFile)))
+	}
+	if c.Timeout > 0 {
+		opts = append(opts, WithTimeout(c.Timeout))
+	}
+	if c.RetryAttempts > 0 {
+		attempts := c.RetryAttempts
+		opts = append(opts, func(s *ClientSettings) { s.RetryPolicy.MaxAttempts = attempts })
+	}
+	if c.RateLimit > 0 {
+		opts = append(opts, WithRateLimit(c.RateLimit, max(c.RateBurst, 1)))
+	}
+	if c.MaxConcurrency > 0 {
+		opts = append(opts, WithMaxConcurrency(c.MaxConcurrency))
+	}
+	if c.UploadEndpoint != "" {
+		opts = append(opts, WithUploadEndpoint(c.UploadEndpoint))
+	}
+	if c.DownloadEndpoint != "" {
+		opts = append(opts, WithDownloadEndpoint(c.DownloadEndpoint))
+	}
+	if c.LogTrailEndpoint != "" {
+		opts = append(opts, WithLogTrailEndpoint(c.LogTrailEndpoint))
+	}
+	return opts
+}
+
+// NewHawkeyeClientFromConfig validates cfg and builds a client from it. opts
+// are applied after the settings from cfg. cfg needs no token when opts
+// include WithTokenSource.
+func NewHawkeyeClientFromConfig(cfg *Config, opts ...Option) (*ClientSettings, error) {
+	if err := cfg.validate(!hasTokenSource(opts)); err != nil {
+		return nil, err
+	}
+	return NewHawkeyeClient(cfg.Token, append(cfg.Options(), opts...)...), nil
+}
+
+// NewHawkeyeClientFromEnv builds a client from LoadConfig's defaults: the
+// profile named by HAWKEYE_PROFILE in the config file named by
+// HAWKEYE_CONFIG or DefaultConfigFile, overridden by HAWKEYE_* variables.
+// As with NewHawkeyeClientFromConfig, no token is needed when opts include
+// WithTokenSource.
+func NewHawkeyeClientFromEnv(opts ...Option) (*ClientSettings, error) {
+	cfg, err := LoadConfig(func(o *configOptions) { o.tokenOptional = hasTokenSource(opts) })
+	if err != nil {
+		return nil, err
+	}
+	return NewHawkeyeClientFromConfig(cfg, opts...)
+}
+
+// hasTokenSource reports whether opts set a TokenSource.
+func hasTokenSource(opts []Option) bool {
+	var probe ClientSettings
+	for _, opt := range opts {
+		opt(&probe)
+	}
+	return probe.TokenSource != nil
+}
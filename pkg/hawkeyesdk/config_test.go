@@ -0,0 +1,196 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func envMap(values map[string]string) ConfigOption {
+	return WithConfigEnv(func(name string) (string, bool) {
+		v, ok := values[name]
+		return v, ok
+	})
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return path
+}
+
+const testConfigFile = `
+# shared settings
+timeout = 30s
+token = "shared-token"
+
+[prod]
+retry_attempts = 5
+
+[qa]
+token = qa-token
+
+[sandbox]
+base_url = https://sandbox.example.com/api/
+environment = qa
+rate_limit = 2.5
+rate_burst = 5
+max_concurrency = 4
+`
+
+func TestLoadConfig_Profiles(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfigFile(t, testConfigFile)
+
+	prod, err := LoadConfig(WithConfigFile(path), WithProfile("prod"), envMap(nil))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if prod.Token != "shared-token" || prod.Environment != PROD || prod.Timeout != 30*time.Second || prod.RetryAttempts != 5 {
+		t.Fatalf("unexpected prod config: %+v", prod)
+	}
+
+	qa, err := LoadConfig(WithConfigFile(path), envMap(map[string]string{EnvProfile: "qa"}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if qa.Token != "qa-token" || qa.Environment != DEV || qa.Profile != "qa" {
+		t.Fatalf("unexpected qa config: %+v", qa)
+	}
+
+	sandbox, err := LoadConfig(WithConfigFile(path), WithProfile("sandbox"), envMap(nil))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sandbox.BaseURL != "https://sandbox.example.com/api/" || sandbox.RateLimit != 2.5 || sandbox.MaxConcurrency != 4 {
+		t.Fatalf("unexpected sandbox config: %+v", sandbox)
+	}
+
+	client, err := NewHawkeyeClientFromConfig(sandbox)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if client.BaseUrl != "https://sandbox.example.com/api" || !client.DevEnv || client.RateLimiter == nil || cap(client.inFlight) != 4 {
+		t.Fatalf("unexpected client settings: %+v", client)
+	}
+	if client.Timeout != 30*time.Second || client.AuthToken != "shared-token" {
+		t.Fatalf("expected timeout and token to be applied, got %v and %q", client.Timeout, client.AuthToken)
+	}
+
+	if _, err := LoadConfig(WithConfigFile(path), WithProfile("staging"), envMap(nil)); !errors.Is(err, ErrConfig) {
+		t.Fatalf("expected missing profile to be rejected, got %v", err)
+	}
+}
+
+func TestLoadConfig_EnvironmentOverrides(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfigFile(t, testConfigFile)
+	tokenFile := writeConfigFile(t, "file-token\n")
+
+	cfg, err := LoadConfig(envMap(map[string]string{
+		EnvConfigFile:        path,
+		EnvProfile:           "sandbox",
+		"HAWKEYE_TOKEN_FILE": tokenFile,
+		"HAWKEYE_ENV":        "prod",
+		"HAWKEYE_TIMEOUT":    "5s",
+	}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Token != "" || cfg.TokenFile != tokenFile || cfg.Environment != PROD || cfg.Timeout != 5*time.Second {
+		t.Fatalf("expected environment to override the file, got %+v", cfg)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer file-token" {
+			t.Errorf("unexpected authorization header %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg.BaseURL = server.URL
+	client, err := NewHawkeyeClientFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := client.Claims.GetClaims(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Without a config file, the environment alone is enough.
+	cfg, err = LoadConfig(WithConfigFile(""), envMap(map[string]string{"HAWKEYE_TOKEN": "env-token"}))
+	if err != nil || cfg.Token != "env-token" || cfg.Profile != DefaultProfile {
+		t.Fatalf("unexpected config %+v, %v", cfg, err)
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+		want    string
+	}{
+		{name: "unknown key", content: "tokn = x\n", want: "config:1: tokn: unknown key"},
+		{name: "bad duration", content: "token = x\n\ntimeout = soon\n", want: "config:3: timeout"},
+		{name: "bad section", content: "[prod\n", want: "unterminated section"},
+		{name: "duplicate section", content: "[a]\n[a]\n", want: "duplicate section"},
+		{name: "no equals", content: "token\n", want: "expected key = value"},
+		{name: "no token", content: "timeout = 1s\n", want: "no token"},
+		{name: "bad base url", content: "token = x\nbase_url = example.com\n", want: "not an http(s) URL"},
+		{name: "bad env var", content: "token = x\n", env: map[string]string{"HAWKEYE_ENVIRONMENT": "staging"}, want: "HAWKEYE_ENVIRONMENT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, tt.content)
+			_, err := LoadConfig(WithConfigFile(path), envMap(tt.env))
+			if !errors.Is(err, ErrConfig) || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected ErrConfig containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := LoadConfig(WithConfigFile(filepath.Join(t.TempDir(), "missing")), envMap(nil)); !errors.Is(err, ErrConfig) {
+		t.Fatalf("expected a named missing file to be an error, got %v", err)
+	}
+}
+
+func TestNewHawkeyeClientFromConfig_TokenSource(t *testing.T) {
+	cfg := &Config{Environment: PROD}
+	if _, err := NewHawkeyeClientFromConfig(cfg); !errors.Is(err, ErrConfig) {
+		t.Fatalf("expected a config without a token to be rejected, got %v", err)
+	}
+	client, err := NewHawkeyeClientFromConfig(cfg, WithTokenSource(StaticToken("from-source")))
+	if err != nil {
+		t.Fatalf("expected a token source to make the token optional, got %v", err)
+	}
+	if token, err := client.token(context.Background()); err != nil || token != "from-source" {
+		t.Fatalf("unexpected token %q, %v", token, err)
+	}
+
+	t.Setenv("HAWKEYE_CONFIG", writeConfigFile(t, "environment = dev\n"))
+	for _, name := range []string{"HAWKEYE_PROFILE", "HAWKEYE_TOKEN", "HAWKEYE_TOKEN_FILE", "HAWKEYE_ENVIRONMENT", "HAWKEYE_ENV"} {
+		t.Setenv(name, "")
+	}
+	if _, err := NewHawkeyeClientFromEnv(); !errors.Is(err, ErrConfig) {
+		t.Fatalf("expected an environment without a token to be rejected, got %v", err)
+	}
+	if client, err := NewHawkeyeClientFromEnv(WithTokenSource(StaticToken("from-source"))); err != nil || !client.DevEnv {
+		t.Fatalf("expected a token source to make the token optional, got %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package hawkeyesdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Layouts used by the Hawkeye API. Claim endpoints expect DateLayoutISO,
+// log trail entries expect DateLayoutUS.
+const (
+	DateLayoutISO = "2006-01-02"
+	DateLayoutUS  = "01/02/2006"
+)
+
+// apiTimeLayouts lists the date formats seen in Hawkeye responses, most
+// specific first.
+var apiTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	DateLayoutISO,
+	"01/02/2006 15:04:05",
+	"01/02/2006 3:04:05 PM",
+	DateLayoutUS,
+	"1/2/2006 3:04:05 PM",
+	"1/2/2006",
+}
+
+// zeroAPIDates are placeholders the API sends for unset dates.
+var zeroAPIDates = []string{"", "0000-00-00", "0000-00-00 00:00:00", "00/00/0000"}
+
+// parseAPITime parses a date string returned by the API. Values without a
+// zone are interpreted in loc.
+func parseAPITime(s string, loc *time.Location) (time.Time, error) {
+	t, _, err := parseAPITimeLayout(s, loc)
+	return t, err
+}
+
+func parseAPITimeLayout(s string, loc *time.Location) (time.Time, string, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range apiTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, layout, nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("unrecognized date format %q", s)
+}
+
+// inDayRange reports whether t falls on a day from from through to, comparing
+// calendar dates each in its own location. A zero bound is open.
+func inDayRange(t, from, to time.Time) bool {
+	day := civilDay(t)
+	if !from.IsZero() && day < civilDay(from) {
+		return false
+	}
+	if !to.IsZero() && day > civilDay(to) {
+		return false
+	}
+	return true
+}
+
+func civilDay(t time.Time) int {
+	y, m, d := t.Date()
+	return y*10000 + int(m)*100 + d
+}
+
+// Date is a date exchanged with the API. It accepts every format the API is
+// known to return, remembers the layout it was decoded from and marshals back
+// in that layout. Values without a zone are interpreted as UTC. Text in an
+// unknown format decodes with a zero Time and keeps the text, see Raw, rather
+// than failing the whole response. The zero Date marshals to JSON as null.
+type Date struct {
+	time.Time
+	layout string
+	raw    string
+}
+
+// NewDate returns the calendar date of t, in t's location, marshaling as
+// YYYY-MM-DD.
+func NewDate(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Time: time.Date(y, m, d, 0, 0, 0, 0, time.UTC), layout: DateLayoutISO}
+}
+
+// ParseDate parses s in any format accepted by Date.
+func ParseDate(s string) (Date, error) {
+	if isZeroAPIDate(s) {
+		return Date{}, nil
+	}
+	t, layout, err := parseAPITimeLayout(s, time.UTC)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{Time: t, layout: layout}, nil
+}
+
+func isZeroAPIDate(s string) bool {
+	s = strings.TrimSpace(s)
+	for _, zero := range zeroAPIDates {
+		if s == zero {
+			return true
+		}
+	}
+	return false
+}
+
+// WithLayout returns d marshaling with layout instead, e.g. DateLayoutUS.
+func (d Date) WithLayout(layout string) Date {
+	d.layout = layout
+	return d
+}
+
+// Layout returns the layout d marshals with.
+func (d Date) Layout() string {
+	if d.layout == "" {
+		return DateLayoutISO
+	}
+	return d.layout
+}
+
+// Raw returns the text d was decoded from when it could not be parsed, and
+// "" otherwise.
+func (d Date) Raw() string {
+	return d.raw
+}
+
+// IsZero reports whether d holds neither a date nor unparsed text, so that
+// omitzero only drops dates that were never set. Use d.Time.IsZero to check
+// whether d was parsed.
+func (d Date) IsZero() bool {
+	return d.Time.IsZero() && d.raw == ""
+}
+
+// String formats d in its layout. A Date that was not parsed returns its Raw
+// text.
+func (d Date) String() string {
+	if d.Time.IsZero() {
+		return d.raw
+	}
+	return d.Format(d.Layout())
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Date{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		*d = Date{raw: string(data)}
+		return nil
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		parsed = Date{raw: string(text)}
+	}
+	*d = parsed
+	return nil
+}
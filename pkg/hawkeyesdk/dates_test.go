@@ -0,0 +1,151 @@
+package hawkeyesdk
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDate_RoundTripKeepsLayout(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]time.Time{
+		`"2024-01-15"`:                time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		`"01/15/2024"`:                time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		`"2024-01-15 13:45:00"`:       time.Date(2024, 1, 15, 13, 45, 0, 0, time.UTC),
+		`"2024-01-15T13:45:00Z"`:      time.Date(2024, 1, 15, 13, 45, 0, 0, time.UTC),
+		`"01/15/2024 1:45:00 PM"`:     time.Date(2024, 1, 15, 13, 45, 0, 0, time.UTC),
+		`"2024-01-15T08:45:00-05:00"`: time.Date(2024, 1, 15, 13, 45, 0, 0, time.UTC),
+	}
+	for input, want := range cases {
+		var d Date
+		if err := json.Unmarshal([]byte(input), &d); err != nil {
+			t.Fatalf("%s: unexpected error %v", input, err)
+		}
+		if !d.Equal(want) {
+			t.Fatalf("%s: parsed as %v, expected %v", input, d.Time, want)
+		}
+		out, err := json.Marshal(d)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", input, err)
+		}
+		if string(out) != input {
+			t.Fatalf("expected %s to round trip, got %s", input, out)
+		}
+	}
+}
+
+func TestDate_ZeroAndInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{`""`, `null`, `"0000-00-00"`, `"0000-00-00 00:00:00"`} {
+		d := NewDate(time.Now())
+		if err := json.Unmarshal([]byte(input), &d); err != nil || !d.IsZero() {
+			t.Fatalf("%s: expected zero date, got %v, %v", input, d, err)
+		}
+	}
+
+	out, _ := json.Marshal(Date{})
+	if string(out) != `null` {
+		t.Fatalf("expected zero date to marshal as null, got %s", out)
+	}
+
+	// Unknown formats keep their text instead of failing the decode.
+	for input, raw := range map[string]string{`"next tuesday"`: "next tuesday", `20240115`: "20240115"} {
+		var d Date
+		if err := json.Unmarshal([]byte(input), &d); err != nil {
+			t.Fatalf("%s: expected no error, got %v", input, err)
+		}
+		if !d.Time.IsZero() || d.IsZero() || d.Raw() != raw {
+			t.Fatalf("%s: expected a zero time keeping %q, got %v, %q", input, raw, d.Time, d.Raw())
+		}
+	}
+	var d Date
+	_ = json.Unmarshal([]byte(`"next tuesday"`), &d)
+	if out, _ := json.Marshal(d); string(out) != `"next tuesday"` {
+		t.Fatalf("expected the raw text to round trip, got %s", out)
+	}
+	if _, err := ParseDate("next tuesday"); err == nil {
+		t.Fatalf("expected ParseDate to reject an unknown format")
+	}
+}
+
+// mustParseDate parses s for test fixtures.
+func mustParseDate(t *testing.T, s string) Date {
+	t.Helper()
+	d, err := ParseDate(s)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return d
+}
+
+func TestNewDate(t *testing.T) {
+	t.Parallel()
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	instant := time.Date(2024, 3, 1, 2, 0, 0, 0, tokyo)
+
+	if got := NewDate(instant).String(); got != "2024-03-01" {
+		t.Fatalf("expected calendar day in the time's own zone, got %s", got)
+	}
+	if got := NewDate(instant.UTC()).String(); got != "2024-02-29" {
+		t.Fatalf("expected UTC calendar day, got %s", got)
+	}
+	if got := NewDate(instant).WithLayout(DateLayoutUS).String(); got != "03/01/2024" {
+		t.Fatalf("expected US layout, got %s", got)
+	}
+}
+
+func TestClaim_DateFields(t *testing.T) {
+	t.Parallel()
+
+	var claim Claim
+	data := `{"filenumber":1,"dateofloss":"03/05/2024","inspectiondate":"2024-03-07 10:00:00","datefileclosed":""}`
+	if err := json.Unmarshal([]byte(data), &claim); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if claim.DateOfLoss.Month() != time.March || claim.DateOfLoss.Day() != 5 {
+		t.Fatalf("unexpected date of loss: %v", claim.DateOfLoss)
+	}
+	if claim.InspectionDate.Hour() != 10 {
+		t.Fatalf("unexpected inspection date: %v", claim.InspectionDate)
+	}
+	if !claim.DateFileClosed.IsZero() {
+		t.Fatalf("expected empty close date to be zero")
+	}
+
+	var admin AdminClaim
+	data = `{"filenumber":2,"demanddate":"Q3 2024","docfiles":[{"doctype":"Images","dateadded":"03/06/2024 9:15:00 AM"}],"logtrail":[{"date":"2024-03-08","activity":"x"}]}`
+	if err := json.Unmarshal([]byte(data), &admin); err != nil {
+		t.Fatalf("expected an unknown date format not to fail the claim, got %v", err)
+	}
+	if admin.DemandDate.Raw() != "Q3 2024" {
+		t.Fatalf("expected the raw demand date to be kept, got %q", admin.DemandDate.Raw())
+	}
+	if admin.DocFiles[0].DateAdded.Hour() != 9 || admin.LogTrail[0].Date.Day() != 8 {
+		t.Fatalf("unexpected nested dates: %+v, %+v", admin.DocFiles[0], admin.LogTrail[0])
+	}
+}
+
+func TestClaim_MarshalOmitsUnsetDates(t *testing.T) {
+	t.Parallel()
+
+	out, err := json.Marshal(Claim{Filenumber: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(out) != `{"filenumber":1}` {
+		t.Fatalf("expected unset dates to be omitted, got %s", out)
+	}
+
+	claim := Claim{Filenumber: 1, DateOfLoss: mustParseDate(t, "03/05/2024"), InspectionDate: Date{raw: "next tuesday"}}
+	out, err = json.Marshal(claim)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	// Unparsed dates are not zero, so their text is sent back.
+	if want := `{"filenumber":1,"dateofloss":"03/05/2024","inspectiondate":"next tuesday"}`; string(out) != want {
+		t.Fatalf("expected %s, got %s", want, out)
+	}
+}
@@ -1,11 +1,28 @@
 package hawkeyesdk
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"log/slog"
+	"math/rand/v2"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 type UploadFileOption func(*uploadFileOptions)
@@ -14,11 +31,22 @@ type uploadFileOptions struct {
 	category        DocType
 	visibleToClient bool
 	notes           string
+	progress        ProgressFunc
+	chunkSize       int64
+	chunkRetries    int
+	categorySet     bool
+	classifier      *DocTypeClassifier
+	minConfidence   float64
 }
 
+// ProgressFunc receives the number of content bytes sent so far and the
+// total size, or -1 when the size is unknown.
+type ProgressFunc func(sent, total int64)
+
 func WithCategory(category DocType) UploadFileOption {
 	return func(opts *uploadFileOptions) {
 		opts.category = category
+		opts.categorySet = true
 	}
 }
 
@@ -34,6 +62,14 @@ func WithNotes(notes string) UploadFileOption {
 	}
 }
 
+// WithProgress reports upload progress for UploadReader, UploadBytes,
+// UploadPath and resumable uploads.
+func WithProgress(fn ProgressFunc) UploadFileOption {
+	return func(opts *uploadFileOptions) {
+		opts.progress = fn
+	}
+}
+
 type DocFilesService struct {
 	client *ClientSettings
 }
@@ -43,25 +79,49 @@ func NewDocFilesService(client *ClientSettings) *DocFilesService {
 	return &DocFilesService{client: client}
 }
 
-func (s *DocFilesService) UploadFile(filenumber int, fileurl string, opts ...UploadFileOption) (ApiResponse, error) {
+func newUploadFileOptions(opts []UploadFileOption) uploadFileOptions {
 	options := uploadFileOptions{
 		category:        DEFAULT,
 		visibleToClient: false,
 		notes:           "",
+		chunkSize:       defaultChunkSize,
+		chunkRetries:    defaultChunkRetries,
 	}
 	for _, opt := range opts {
 		opt(&options)
 	}
+	return options
+}
 
-	type postData struct {
-		Filenumber      int    `json:"filenumber"`
-		Link            string `json:"link"`
-		Category        string `json:"category"`
-		VisibleToClient bool   `json:"visible_to_client"`
-		Notes           string `json:"notes"`
-	}
+// UploadFile is UploadFileContext with a background context.
+func (s *DocFilesService) UploadFile(filenumber int, fileurl string, opts ...UploadFileOption) (ApiResponse, error) {
+	return s.UploadFileContext(context.Background(), filenumber, fileurl, opts...)
+}
+
+type uploadLinkPayload struct {
+	Filenumber      int    `json:"filenumber"`
+	Link            string `json:"link"`
+	Category        string `json:"category"`
+	VisibleToClient bool   `json:"visible_to_client"`
+	Notes           string `json:"notes"`
+}
+
+// LogValue keeps the link out of logs; pre-signed URLs carry credentials.
+func (p uploadLinkPayload) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("filenumber", p.Filenumber),
+		slog.String("link", redactURL(p.Link)),
+		slog.String("category", p.Category),
+		slog.Bool("visible_to_client", p.VisibleToClient),
+	)
+}
+
+// UploadFileContext registers a document that Hawkeye downloads from fileurl.
+func (s *DocFilesService) UploadFileContext(ctx context.Context, filenumber int, fileurl string, opts ...UploadFileOption) (ApiResponse, error) {
+	options := newUploadFileOptions(opts)
+	options.autoCategorize(filenameFromURL(fileurl), "", nil)
 
-	payload := postData{
+	payload := uploadLinkPayload{
 		Filenumber:      filenumber,
 		Link:            fileurl,
 		Category:        options.category.String(),
@@ -74,25 +134,94 @@ func (s *DocFilesService) UploadFile(filenumber int, fileurl string, opts ...Upl
 		return ApiResponse{}, fmt.Errorf("failed to marshal post data: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, s.client.BaseUrl+"/savefile", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return ApiResponse{}, fmt.Errorf("failed to create request: %w", err)
+	if _, err := s.client.do(ctx, apiRequest{
+		operation:   "UploadFile",
+		method:      http.MethodPost,
+		url:         s.client.BaseUrl + "/savefile",
+		body:        jsonData,
+		contentType: "application/json",
+		filenumber:  filenumber,
+		payload:     payload,
+	}); err != nil {
+		return ApiResponse{}, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.client.AuthToken))
+	return ApiResponse{
+		Message: "File uploaded successfully",
+		Success: true,
+	}, nil
+}
+
+// UploadReader streams the contents of r to Hawkeye as a multipart upload.
+// The reader is consumed once, so the upload is never retried. An empty
+// contentType is sniffed from the first bytes of the content.
+func (s *DocFilesService) UploadReader(ctx context.Context, filenumber int, filename, contentType string, r io.Reader, opts ...UploadFileOption) (ApiResponse, error) {
+	br := bufio.NewReaderSize(r, sniffLength)
+	head, _ := br.Peek(sniffLength)
+
+	var used bool
+	open := func() (io.ReadCloser, error) {
+		if used {
+			return nil, errors.New("upload reader cannot be replayed")
+		}
+		used = true
+		return io.NopCloser(br), nil
+	}
+	return s.uploadContent(ctx, filenumber, filename, contentType, head, -1, open, opts)
+}
 
-	resp, err := s.client.HTTPClient.Do(req)
+func (s *DocFilesService) UploadBytes(ctx context.Context, filenumber int, filename, contentType string, data []byte, opts ...UploadFileOption) (ApiResponse, error) {
+	open := func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return s.uploadContent(ctx, filenumber, filename, contentType, data[:min(len(data), sniffLength)], int64(len(data)), open, opts)
+}
+
+// UploadPath streams a local file without loading it into memory. The
+// content type is derived from the file extension when possible.
+func (s *DocFilesService) UploadPath(ctx context.Context, filenumber int, path string, opts ...UploadFileOption) (ApiResponse, error) {
+	info, err := os.Stat(path)
 	if err != nil {
-		return ApiResponse{}, fmt.Errorf("request failed: %w", err)
+		return ApiResponse{}, fmt.Errorf("failed to open upload file: %w", err)
+	}
+	open := func() (io.ReadCloser, error) {
+		return os.Open(path)
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	return s.uploadContent(ctx, filenumber, filepath.Base(path), contentType, nil, info.Size(), open, opts)
+}
+
+func (s *DocFilesService) uploadContent(ctx context.Context, filenumber int, filename, contentType string, head []byte, size int64, open func() (io.ReadCloser, error), opts []UploadFileOption) (ApiResponse, error) {
+	if s.client.UploadEndpoint == "" {
+		return ApiResponse{}, newSentinelError(ErrConfig, "no multipart upload endpoint is configured, see WithUploadEndpoint")
+	}
+
+	options := newUploadFileOptions(opts)
+	options.autoCategorize(filename, contentType, head)
+	if contentType == "" && head != nil {
+		contentType = http.DetectContentType(head)
 	}
-	defer resp.Body.Close()
 
-	if _, err := io.ReadAll(resp.Body); err != nil {
-		return ApiResponse{}, fmt.Errorf("failed to read response body: %w", err)
+	fields := []multipartField{
+		{name: "filenumber", value: strconv.Itoa(filenumber)},
+		{name: "category", value: options.category.String()},
+		{name: "visible_to_client", value: strconv.FormatBool(options.visibleToClient)},
+		{name: "notes", value: options.notes},
 	}
 
-	if err := checkResponse(resp); err != nil {
+	body := newMultipartBody(fields, filename, contentType, open)
+	body.size = size
+	body.progress = options.progress
+
+	if _, err := s.client.do(ctx, apiRequest{
+		operation:   "UploadContent",
+		method:      http.MethodPost,
+		url:         s.client.BaseUrl + "/" + strings.TrimLeft(s.client.UploadEndpoint, "/"),
+		getBody:     body.reader,
+		contentType: body.contentType(),
+		filenumber:  filenumber,
+		payload:     map[string]any{"filename": filename, "category": options.category.String()},
+	}); err != nil {
 		return ApiResponse{}, err
 	}
 
@@ -101,3 +230,297 @@ func (s *DocFilesService) UploadFile(filenumber int, fileurl string, opts ...Upl
 		Success: true,
 	}, nil
 }
+
+type multipartField struct {
+	name  string
+	value string
+}
+
+// multipartBody streams form fields followed by a single file part through a
+// pipe so the file never has to fit in memory. Every call to reader returns a
+// fresh stream with the same boundary.
+type multipartBody struct {
+	boundary string
+	fields   []multipartField
+	filename string
+	fileType string
+	open     func() (io.ReadCloser, error)
+	size     int64
+	progress ProgressFunc
+}
+
+// sniffLength matches the amount of data http.DetectContentType considers.
+const sniffLength = 512
+
+func newMultipartBody(fields []multipartField, filename, fileType string, open func() (io.ReadCloser, error)) *multipartBody {
+	return &multipartBody{
+		boundary: multipart.NewWriter(io.Discard).Boundary(),
+		fields:   fields,
+		filename: filename,
+		fileType: fileType,
+		open:     open,
+		size:     -1,
+	}
+}
+
+func (b *multipartBody) contentType() string {
+	return "multipart/form-data; boundary=" + b.boundary
+}
+
+func (b *multipartBody) reader() (io.Reader, error) {
+	src, err := b.open()
+	if err != nil {
+		return nil, err
+	}
+	return &multipartStream{body: b, src: src}, nil
+}
+
+// length returns the encoded size of the body, or -1 when the content size
+// or type is not known up front.
+func (b *multipartBody) length() int64 {
+	if b.size < 0 || b.fileType == "" {
+		return -1
+	}
+	var n countingWriter
+	if err := b.write(&n, strings.NewReader("")); err != nil {
+		return -1
+	}
+	return int64(n) + b.size
+}
+
+type countingWriter int64
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
+
+// multipartStream writes the body from a goroutine started by the first
+// Read, so a request that fails before it is sent leaves nothing running.
+// Close stops the writer and closes the content.
+type multipartStream struct {
+	body *multipartBody
+	src  io.ReadCloser
+
+	once sync.Once
+	pr   *io.PipeReader
+}
+
+func (s *multipartStream) start() {
+	pr, pw := io.Pipe()
+	s.pr = pr
+	go func() {
+		defer s.src.Close()
+		pw.CloseWithError(s.body.write(pw, s.src))
+	}()
+}
+
+func (s *multipartStream) Read(p []byte) (int, error) {
+	s.once.Do(s.start)
+	if s.pr == nil {
+		return 0, io.ErrClosedPipe
+	}
+	return s.pr.Read(p)
+}
+
+func (s *multipartStream) Close() error {
+	s.once.Do(func() { s.src.Close() })
+	if s.pr != nil {
+		return s.pr.Close()
+	}
+	return nil
+}
+
+// Size lets the request set ContentLength when the length is known.
+func (s *multipartStream) Size() int64 {
+	return s.body.length()
+}
+
+func (b *multipartBody) write(w io.Writer, src io.Reader) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(b.boundary); err != nil {
+		return err
+	}
+
+	for _, field := range b.fields {
+		if err := mw.WriteField(field.name, field.value); err != nil {
+			return err
+		}
+	}
+
+	fileType := b.fileType
+	if fileType == "" {
+		buffered := bufio.NewReaderSize(src, sniffLength)
+		head, _ := buffered.Peek(sniffLength)
+		fileType = http.DetectContentType(head)
+		src = buffered
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+		"name":     "file",
+		"filename": b.filename,
+	}))
+	header.Set("Content-Type", fileType)
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if b.progress != nil {
+		src = &progressReader{r: src, total: b.size, fn: b.progress}
+	}
+	if _, err := io.Copy(part, src); err != nil {
+		return fmt.Errorf("failed to read upload content: %w", err)
+	}
+
+	return mw.Close()
+}
+
+type progressReader struct {
+	r     io.Reader
+	sent  int64
+	total int64
+	fn    ProgressFunc
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		p.sent += int64(n)
+		p.fn(p.sent, p.total)
+	}
+	return n, err
+}
+
+type ListDocFilesOption func(*listDocFilesOptions)
+
+type listDocFilesOptions struct {
+	docTypes []DocType
+	from     time.Time
+	to       time.Time
+}
+
+// WithDocTypeFilter keeps only documents of the given types.
+func WithDocTypeFilter(types ...DocType) ListDocFilesOption {
+	return func(opts *listDocFilesOptions) {
+		opts.docTypes = append(opts.docTypes, types...)
+	}
+}
+
+// WithDateAddedRange keeps documents added on the days from from through to,
+// inclusive; only the calendar date of each bound is used. A zero bound is
+// open. Documents without a readable DateAdded are left out.
+func WithDateAddedRange(from, to time.Time) ListDocFilesOption {
+	return func(opts *listDocFilesOptions) {
+		opts.from = from
+		opts.to = to
+	}
+}
+
+func (o listDocFilesOptions) matches(doc DocFile) bool {
+	if len(o.docTypes) > 0 && !slices.Contains(o.docTypes, doc.Doctype) {
+		return false
+	}
+	if o.from.IsZero() && o.to.IsZero() {
+		return true
+	}
+	return !doc.DateAdded.Time.IsZero() && inDayRange(doc.DateAdded.Time, o.from, o.to)
+}
+
+// ListDocFiles returns the documents attached to a claim, as listed by
+// GetAdminClaims with WithDocFiles. Filters are applied locally. It fails
+// with ErrNotFound when there is no claim with that filenumber.
+func (s *DocFilesService) ListDocFiles(ctx context.Context, filenumber int, opts ...ListDocFilesOption) ([]DocFile, error) {
+	var options listDocFilesOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	claims := &ClaimsService{client: s.client}
+	request := claims.adminClaimsRequest(getAdminClaimsOptions{filenumber: &filenumber, includeInactive: true, docfiles: true})
+	request.operation = "ListDocFiles"
+
+	var found []AdminClaim
+	if err := s.client.doJSON(ctx, request, &found); err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(found, func(c AdminClaim) bool { return c.Filenumber == filenumber })
+	if i < 0 {
+		return nil, request.notFound("no claim found with filenumber %d", filenumber)
+	}
+
+	filtered := make([]DocFile, 0, len(found[i].DocFiles))
+	for _, doc := range found[i].DocFiles {
+		if options.matches(doc) {
+			filtered = append(filtered, doc)
+		}
+	}
+
+	return filtered, nil
+}
+
+// DownloadDocFile streams a document from the endpoint set with
+// WithDownloadEndpoint. The caller must close the returned reader.
+func (s *DocFilesService) DownloadDocFile(ctx context.Context, filenumber int, filename string) (io.ReadCloser, error) {
+	if s.client.DownloadEndpoint == "" {
+		return nil, newSentinelError(ErrConfig, "no document download endpoint is configured, see WithDownloadEndpoint")
+	}
+
+	endpoint := strings.Trim(s.client.DownloadEndpoint, "/")
+	resp, err := s.client.send(ctx, apiRequest{
+		operation:  "DownloadDocFile",
+		method:     http.MethodGet,
+		url:        s.client.BaseUrl + fmt.Sprintf("/%s/%d/%s", endpoint, filenumber, url.PathEscape(filename)),
+		filenumber: filenumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// SaveDocFile downloads a document to path and returns the number of bytes
+// written. The file only appears at path once the download has completed.
+func (s *DocFilesService) SaveDocFile(ctx context.Context, filenumber int, filename, path string) (int64, error) {
+	body, err := s.DownloadDocFile(ctx, filenumber, filename)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	tmp, err := createPartFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, body)
+	if err != nil {
+		tmp.Close()
+		return written, fmt.Errorf("failed to download document: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return written, fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return written, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return written, nil
+}
+
+// createPartFile creates a temporary file next to path. Unlike os.CreateTemp
+// it uses mode 0666 before the umask, so the renamed file gets the same
+// permissions as any other new file.
+func createPartFile(path string) (*os.File, error) {
+	dir, base := filepath.Split(path)
+	for range 100 {
+		name := filepath.Join(dir, "."+base+"."+strconv.FormatUint(rand.Uint64(), 36)+".part")
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o666)
+		if !errors.Is(err, fs.ErrExist) {
+			return f, err
+		}
+	}
+	return nil, fmt.Errorf("could not create a temporary file for %s", path)
+}
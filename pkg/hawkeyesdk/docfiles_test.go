@@ -1,10 +1,18 @@
 package hawkeyesdk
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDocFilesService_UploadFile_Success(t *testing.T) {
@@ -107,3 +115,407 @@ func TestDocFilesService_UploadFile_HTTPError(t *testing.T) {
 		t.Fatalf("expected an error")
 	}
 }
+
+func TestDocFilesService_UploadFileContext_Canceled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request should not reach the server")
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewDocFilesService(client).UploadFileContext(ctx, 1, "https://file")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+type uploadedPart struct {
+	length      int64
+	fields      map[string]string
+	filename    string
+	contentType string
+	content     string
+}
+
+func newUploadServer(t *testing.T, got *uploadedPart) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/uploadfile" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("unexpected auth header: %s", got)
+		}
+
+		got.length = r.ContentLength
+		mr, err := r.MultipartReader()
+		if err != nil {
+			t.Errorf("expected multipart body: %v", err)
+			return
+		}
+
+		got.fields = map[string]string{}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Errorf("failed to read part: %v", err)
+				return
+			}
+			data, _ := io.ReadAll(part)
+			if part.FormName() == "file" {
+				got.filename = part.FileName()
+				got.contentType = part.Header.Get("Content-Type")
+				got.content = string(data)
+				continue
+			}
+			got.fields[part.FormName()] = string(data)
+		}
+
+		_ = json.NewEncoder(w).Encode(ApiResponse{Message: "ok", Success: true})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestDocFilesService_UploadReader_Multipart(t *testing.T) {
+	t.Parallel()
+
+	var got uploadedPart
+	server := newUploadServer(t, &got)
+
+	client := &ClientSettings{
+		AuthToken:      "token",
+		BaseUrl:        server.URL,
+		HTTPClient:     server.Client(),
+		UploadEndpoint: "/uploadfile",
+	}
+
+	resp, err := NewDocFilesService(client).UploadReader(
+		context.Background(),
+		55,
+		"report.pdf",
+		"application/pdf",
+		strings.NewReader("%PDF-1.7 body"),
+		WithCategory(POLICE_REPORT),
+		WithVisibleToClient(true),
+		WithNotes("from scanner"),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success response")
+	}
+
+	if got.fields["filenumber"] != "55" || got.fields["category"] != POLICE_REPORT.String() || got.fields["visible_to_client"] != "true" || got.fields["notes"] != "from scanner" {
+		t.Fatalf("unexpected fields: %v", got.fields)
+	}
+	if got.filename != "report.pdf" || got.contentType != "application/pdf" || got.content != "%PDF-1.7 body" {
+		t.Fatalf("unexpected file part: %+v", got)
+	}
+	if got.length != -1 {
+		t.Fatalf("expected a reader of unknown size to be sent chunked, got Content-Length %d", got.length)
+	}
+}
+
+func TestDocFilesService_UploadBytes_SniffsContentType(t *testing.T) {
+	t.Parallel()
+
+	var got uploadedPart
+	server := newUploadServer(t, &got)
+
+	client := &ClientSettings{
+		AuthToken:      "token",
+		BaseUrl:        server.URL,
+		HTTPClient:     server.Client(),
+		UploadEndpoint: "/uploadfile",
+	}
+
+	png := []byte("\x89PNG\r\n\x1a\n0000")
+	if _, err := NewDocFilesService(client).UploadBytes(context.Background(), 1, "photo", "", png); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.contentType != "image/png" || got.content != string(png) {
+		t.Fatalf("unexpected file part: %+v", got)
+	}
+	if got.length <= int64(len(png)) {
+		t.Fatalf("expected a known Content-Length, got %d", got.length)
+	}
+}
+
+func TestDocFilesService_UploadBytes_RequiresEndpoint(t *testing.T) {
+	t.Parallel()
+
+	client := &ClientSettings{AuthToken: "token", BaseUrl: "http://127.0.0.1:0", HTTPClient: http.DefaultClient}
+	if _, err := NewDocFilesService(client).UploadBytes(context.Background(), 1, "a.txt", "text/plain", []byte("a")); !errors.Is(err, ErrConfig) {
+		t.Fatalf("expected ErrConfig, got %v", err)
+	}
+}
+
+func TestMultipartBody_ClosedBeforeRead(t *testing.T) {
+	t.Parallel()
+
+	src := &closeRecorder{Reader: strings.NewReader("content")}
+	body := newMultipartBody(nil, "a.txt", "text/plain", func() (io.ReadCloser, error) { return src, nil })
+	body.size = 7
+
+	r, err := body.reader()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := r.(io.Closer).Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !src.closed {
+		t.Fatalf("expected the content to be closed")
+	}
+	if _, err := r.Read(make([]byte, 1)); err == nil {
+		t.Fatalf("expected a closed stream to fail")
+	}
+
+	// The advertised length matches what is written.
+	r, _ = body.reader()
+	data, err := io.ReadAll(r)
+	if err != nil || int64(len(data)) != r.(interface{ Size() int64 }).Size() {
+		t.Fatalf("expected %d bytes, got %d, %v", r.(interface{ Size() int64 }).Size(), len(data), err)
+	}
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestDocFilesService_UploadPath(t *testing.T) {
+	t.Parallel()
+
+	var got uploadedPart
+	server := newUploadServer(t, &got)
+
+	client := &ClientSettings{
+		AuthToken:      "token",
+		BaseUrl:        server.URL,
+		HTTPClient:     server.Client(),
+		UploadEndpoint: "/uploadfile",
+	}
+
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o600); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	service := NewDocFilesService(client)
+
+	if _, err := service.UploadPath(context.Background(), 9, path, WithCategory(OTHER)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.filename != "notes.txt" || !strings.HasPrefix(got.contentType, "text/plain") || got.content != "hello" {
+		t.Fatalf("unexpected file part: %+v", got)
+	}
+	if got.length <= 0 {
+		t.Fatalf("expected a known Content-Length, got %d", got.length)
+	}
+
+	if _, err := service.UploadPath(context.Background(), 9, filepath.Join(t.TempDir(), "missing.pdf")); err == nil {
+		t.Fatalf("expected error for missing file")
+	}
+}
+
+func TestDocFilesService_UploadBytes_ReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	var got uploadedPart
+	server := newUploadServer(t, &got)
+
+	client := &ClientSettings{
+		AuthToken:      "token",
+		BaseUrl:        server.URL,
+		HTTPClient:     server.Client(),
+		UploadEndpoint: "/uploadfile",
+	}
+
+	data := []byte(strings.Repeat("v", 100_000))
+
+	var lastSent, lastTotal int64
+	_, err := NewDocFilesService(client).UploadBytes(context.Background(), 3, "clip.mp4", "video/mp4", data, WithProgress(func(sent, total int64) {
+		if sent < lastSent {
+			t.Errorf("progress went backwards: %d after %d", sent, lastSent)
+		}
+		lastSent, lastTotal = sent, total
+	}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if lastSent != int64(len(data)) || lastTotal != int64(len(data)) {
+		t.Fatalf("unexpected final progress %d/%d", lastSent, lastTotal)
+	}
+}
+
+func TestDocFilesService_ListDocFiles_Filters(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/getadminclaims" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("filenumber") == "" || query.Get("docfiles") != "true" || query.Get("includeinactive") != "true" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`[{"filenumber": 44, "docfiles": [
+			{"doctype": "Police Report", "dateadded": "2024-01-15 10:00:00", "filename": "old.pdf"},
+			{"doctype": "Police Report", "dateadded": "2024-02-10 09:30:00", "filename": "report.pdf"},
+			{"doctype": "Police Report", "dateadded": "2024-02-29 18:45:00", "filename": "late.pdf"},
+			{"doctype": "Police Report", "dateadded": "2024-03-01 08:00:00", "filename": "march.pdf"},
+			{"doctype": "Police Report", "dateadded": "sometime", "filename": "undated.pdf"},
+			{"doctype": "Police Report", "dateadded": "", "filename": "blank.pdf"},
+			{"doctype": "Images", "dateadded": "2024-02-11 09:30:00", "filename": "car.jpg"}
+		]}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	service := NewDocFilesService(client)
+
+	// The upper bound covers the whole of February 29th.
+	docs, err := service.ListDocFiles(
+		context.Background(),
+		44,
+		WithDocTypeFilter(POLICE_REPORT),
+		WithDateAddedRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var names []string
+	for _, doc := range docs {
+		names = append(names, doc.Filename)
+	}
+	// Documents without a readable date cannot be in any range.
+	if want := []string{"report.pdf", "late.pdf"}; !slices.Equal(names, want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+
+	docs, err = service.ListDocFiles(context.Background(), 44, WithDocTypeFilter(POLICE_REPORT))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(docs) != 6 || docs[4].DateAdded.Raw() != "sometime" || docs[5].Filename != "blank.pdf" {
+		t.Fatalf("expected undated documents without a date range, got %+v", docs)
+	}
+
+	// A response for another claim is not mistaken for this one.
+	if _, err := service.ListDocFiles(context.Background(), 45); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for another claim, got %v", err)
+	}
+}
+
+func TestDocFilesService_DownloadAndSaveDocFile(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/getdocfile/44/police%20report.pdf" {
+			t.Errorf("unexpected path: %s", r.URL.EscapedPath())
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.4 content"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:        "token",
+		BaseUrl:          server.URL,
+		HTTPClient:       server.Client(),
+		DownloadEndpoint: "/getdocfile",
+	}
+	service := NewDocFilesService(client)
+
+	body, err := service.DownloadDocFile(context.Background(), 44, "police report.pdf")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil || string(data) != "%PDF-1.4 content" {
+		t.Fatalf("unexpected download: %q, %v", data, err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.pdf")
+	n, err := service.SaveDocFile(context.Background(), 44, "police report.pdf", path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	saved, _ := os.ReadFile(path)
+	if n != int64(len(saved)) || string(saved) != "%PDF-1.4 content" {
+		t.Fatalf("unexpected saved file (%d bytes): %q", n, saved)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Fatalf("expected temporary files to be cleaned up, found %d entries", len(entries))
+	}
+
+	// The file gets the same permissions as any new file under the umask.
+	plain, err := os.Create(filepath.Join(t.TempDir(), "plain"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	plain.Close()
+	want, _ := os.Stat(plain.Name())
+	got, _ := os.Stat(path)
+	if got.Mode().Perm() != want.Mode().Perm() {
+		t.Fatalf("expected mode %v, got %v", want.Mode().Perm(), got.Mode().Perm())
+	}
+
+	client.DownloadEndpoint = ""
+	if _, err := service.DownloadDocFile(context.Background(), 44, "police report.pdf"); !errors.Is(err, ErrConfig) {
+		t.Fatalf("expected ErrConfig without a download endpoint, got %v", err)
+	}
+}
+
+func TestDocFilesService_SaveDocFile_NotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:        "token",
+		BaseUrl:          server.URL,
+		HTTPClient:       server.Client(),
+		DownloadEndpoint: "/getdocfile",
+	}
+
+	path := filepath.Join(t.TempDir(), "missing.pdf")
+	if _, err := NewDocFilesService(client).SaveDocFile(context.Background(), 1, "missing.pdf", path); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no file to be written")
+	}
+}
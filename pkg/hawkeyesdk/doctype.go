@@ -0,0 +1,318 @@
+package hawkeyesdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// docTypeCodes holds the stable machine code of every DocType, indexed by
+// value. Codes never change once published, unlike the display names.
+var docTypeCodes = [...]string{
+	DEFAULT:                         "DEFAULT",
+	FIRST_REPORT:                    "FIRST_REPORT",
+	SECOND_REPORT:                   "SECOND_REPORT",
+	THIRD_REPORT:                    "THIRD_REPORT",
+	ACKNOWLEDGEMENT:                 "ACKNOWLEDGEMENT",
+	AOB:                             "AOB",
+	ASSIGNMENT_SHEET:                "ASSIGNMENT_SHEET",
+	BILL:                            "BILL",
+	BILL_OF_LADING:                  "BILL_OF_LADING",
+	CALL_RECORDING:                  "CALL_RECORDING",
+	CASH_CALL:                       "CASH_CALL",
+	CHECK_IN_VIDEO:                  "CHECK_IN_VIDEO",
+	CHECK_OUT_VIDEO:                 "CHECK_OUT_VIDEO",
+	CONDITION_REPORT:                "CONDITION_REPORT",
+	CLAIM_DETAILS_REPORT:            "CLAIM_DETAILS_REPORT",
+	CLAIM_STATUS_REPORT:             "CLAIM_STATUS_REPORT",
+	DAMAGE_ASSESSMENT:               "DAMAGE_ASSESSMENT",
+	DEDUCTIBLE_REQUEST_FINAL_NOTICE: "DEDUCTIBLE_REQUEST_FINAL_NOTICE",
+	DEDUCTIBLE_REQUEST_FIRST_NOTICE: "DEDUCTIBLE_REQUEST_FIRST_NOTICE",
+	DELIVERY_CONFIRMATION:           "DELIVERY_CONFIRMATION",
+	DEMAND:                          "DEMAND",
+	DEMAND_LETTER:                   "DEMAND_LETTER",
+	DENIAL_LETTER:                   "DENIAL_LETTER",
+	DRIVER_EXCHANGE:                 "DRIVER_EXCHANGE",
+	DRIVERS_LICENSE:                 "DRIVERS_LICENSE",
+	DV_FORM:                         "DV_FORM",
+	EMAIL:                           "EMAIL",
+	EXPENSE_RECEIPT:                 "EXPENSE_RECEIPT",
+	HC_DAMAGE_APPRAISAL:             "HC_DAMAGE_APPRAISAL",
+	IMAGES:                          "IMAGES",
+	INCIDENT_REPORT:                 "INCIDENT_REPORT",
+	INSURANCE_CARD:                  "INSURANCE_CARD",
+	INVOICE:                         "INVOICE",
+	LIENHOLDER_INFO:                 "LIENHOLDER_INFO",
+	MARKET_VALUATION:                "MARKET_VALUATION",
+	MITIGATION_LETTER:               "MITIGATION_LETTER",
+	NON_HC_DAMAGE_APPRAISAL:         "NON_HC_DAMAGE_APPRAISAL",
+	OTHER:                           "OTHER",
+	PAYMENT_ADVISORY_LETTER:         "PAYMENT_ADVISORY_LETTER",
+	PAYMENT_CONFIRMATION:            "PAYMENT_CONFIRMATION",
+	POLICE_REPORT:                   "POLICE_REPORT",
+	POLICY:                          "POLICY",
+	POA:                             "POA",
+	RECORDED_STATEMENT:              "RECORDED_STATEMENT",
+	REGISTRATION:                    "REGISTRATION",
+	RELEASE:                         "RELEASE",
+	RENTAL_AGREEMENT:                "RENTAL_AGREEMENT",
+	RESERVE_REPORT:                  "RESERVE_REPORT",
+	SETTLEMENT_CHECK:                "SETTLEMENT_CHECK",
+	STATUS_REPORT:                   "STATUS_REPORT",
+	TITLE:                           "TITLE",
+	TOW_BILL:                        "TOW_BILL",
+	TRAILER_INTERCHANGE_AGREEMENT:   "TRAILER_INTERCHANGE_AGREEMENT",
+	VEHICLE_HISTORY:                 "VEHICLE_HISTORY",
+	VEHICLE_SPECIFICATIONS:          "VEHICLE_SPECIFICATIONS",
+	VENDOR_INVOICE:                  "VENDOR_INVOICE",
+	INTERIM_INVOICE:                 "INTERIM_INVOICE",
+	FINAL_INVOICE:                   "FINAL_INVOICE",
+}
+
+// docTypeAliases are extra spellings accepted by ParseDocType on top of the
+// machine codes and display names.
+var docTypeAliases = map[string]DocType{
+	"uncategorized":          DEFAULT,
+	"first report":           FIRST_REPORT,
+	"second report":          SECOND_REPORT,
+	"third report":           THIRD_REPORT,
+	"assignment of benefit":  AOB,
+	"check in video":         CHECK_IN_VIDEO,
+	"checkin video":          CHECK_IN_VIDEO,
+	"drop off video":         CHECK_IN_VIDEO,
+	"check out video":        CHECK_OUT_VIDEO,
+	"checkout video":         CHECK_OUT_VIDEO,
+	"pick up video":          CHECK_OUT_VIDEO,
+	"driver license":         DRIVERS_LICENSE,
+	"drivers licence":        DRIVERS_LICENSE,
+	"diminished value form":  DV_FORM,
+	"photos":                 IMAGES,
+	"pictures":               IMAGES,
+	"lienholder information": LIENHOLDER_INFO,
+	"police":                 POLICE_REPORT,
+	"insurance policy":       POLICY,
+	"ra":                     RENTAL_AGREEMENT,
+	"vendor invoice":         VENDOR_INVOICE,
+}
+
+var docTypeLookup = buildDocTypeLookup()
+
+func buildDocTypeLookup() map[string]DocType {
+	lookup := make(map[string]DocType)
+	for _, dt := range AllDocTypes() {
+		lookup[normalizeDocTypeName(dt.Code())] = dt
+		lookup[normalizeDocTypeName(dt.String())] = dt
+	}
+	for alias, dt := range docTypeAliases {
+		lookup[normalizeDocTypeName(alias)] = dt
+	}
+	return lookup
+}
+
+// normalizeDocTypeName folds case, punctuation and separators so that
+// "Check-in Video (Drop-Off)", "CHECK_IN_VIDEO" and "check in video" compare
+// equal.
+func normalizeDocTypeName(s string) string {
+	s = strings.ToLower(s)
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r == '\'' || r == '.' || r == '(' || r == ')':
+			return -1
+		default:
+			return ' '
+		}
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// AllDocTypes returns every known DocType in declaration order.
+func AllDocTypes() []DocType {
+	types := make([]DocType, 0, len(docTypeCodes))
+	for dt := DEFAULT; dt <= FINAL_INVOICE; dt++ {
+		types = append(types, dt)
+	}
+	return types
+}
+
+func (d DocType) IsValid() bool {
+	return d >= DEFAULT && d <= FINAL_INVOICE
+}
+
+// Code returns the stable machine code of the type, e.g. "POLICE_REPORT".
+func (d DocType) Code() string {
+	if !d.IsValid() {
+		return docTypeCodes[DEFAULT]
+	}
+	return docTypeCodes[d]
+}
+
+// ParseDocType accepts a machine code, a display name, a known alias or a
+// numeric value, ignoring case and punctuation.
+func ParseDocType(s string) (DocType, error) {
+	if dt, ok := docTypeLookup[normalizeDocTypeName(s)]; ok {
+		return dt, nil
+	}
+	if i, err := strconv.Atoi(strings.TrimSpace(s)); err == nil && DocType(i).IsValid() {
+		return DocType(i), nil
+	}
+	return DEFAULT, fmt.Errorf("invalid document type: %s", s)
+}
+
+func (d DocType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d DocType) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText accepts anything ParseDocType does. Types this version of
+// the SDK does not know decode as DEFAULT, so a new type on the server does
+// not break every response that lists documents. A DocFile keeps the text,
+// see DocFile.RawDoctype and CheckDocTypes.
+func (d *DocType) UnmarshalText(text []byte) error {
+	dt, err := ParseDocType(string(text))
+	if err != nil {
+		dt = DEFAULT
+	}
+	*d = dt
+	return nil
+}
+
+// RawDoctype returns the document type as received when this version of the
+// SDK does not know it, and "" otherwise. Doctype is DEFAULT in that case.
+func (f DocFile) RawDoctype() string {
+	return f.rawDoctype
+}
+
+func (f DocFile) MarshalJSON() ([]byte, error) {
+	type plain DocFile
+	out := struct {
+		Doctype any `json:"doctype"`
+		plain
+	}{Doctype: f.Doctype, plain: plain(f)}
+	if f.rawDoctype != "" {
+		out.Doctype = f.rawDoctype
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes the document type as DocType does, keeping the text
+// of a type this version of the SDK does not know so that it round trips.
+func (f *DocFile) UnmarshalJSON(data []byte) error {
+	type plain DocFile
+	in := struct {
+		Doctype json.RawMessage `json:"doctype"`
+		*plain
+	}{plain: (*plain)(f)}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if len(in.Doctype) == 0 || string(in.Doctype) == "null" {
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(in.Doctype, &text); err != nil {
+		text = string(in.Doctype)
+	}
+	dt, err := ParseDocType(text)
+	f.Doctype, f.rawDoctype = dt, ""
+	if err != nil {
+		f.rawDoctype = text
+	}
+	return nil
+}
+
+// CheckDocTypes reports the first document in v whose type this version of
+// the SDK does not know. v is anything holding DocFile values, such as
+// *[]AdminClaim. It applies the WithStrictDocTypes policy to claims decoded
+// outside the client, for example from your own store.
+func CheckDocTypes(v any) error {
+	if v == nil {
+		return nil
+	}
+	return checkDocTypes(reflect.ValueOf(v))
+}
+
+var docFileType = reflect.TypeFor[DocFile]()
+
+func checkDocTypes(v reflect.Value) error {
+	if !mayHoldDocFile(v.Type()) {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			return checkDocTypes(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := checkDocTypes(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for iter := v.MapRange(); iter.Next(); {
+			if err := checkDocTypes(iter.Value()); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if v.Type() == docFileType {
+			if raw := v.FieldByName("rawDoctype").String(); raw != "" {
+				return fmt.Errorf("document %q: invalid document type: %s", v.FieldByName("Filename").String(), raw)
+			}
+			return nil
+		}
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				if err := checkDocTypes(v.Field(i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// docFileHolders caches mayHoldDocFile, so CheckDocTypes only visits the
+// fields of a claim that can lead to a document.
+var docFileHolders sync.Map
+
+func mayHoldDocFile(t reflect.Type) bool {
+	if held, ok := docFileHolders.Load(t); ok {
+		return held.(bool)
+	}
+	held := holdsDocFile(t, make(map[reflect.Type]bool))
+	docFileHolders.Store(t, held)
+	return held
+}
+
+func holdsDocFile(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t == docFileType {
+		return true
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return holdsDocFile(t.Elem(), seen)
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if f := t.Field(i); f.IsExported() && holdsDocFile(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
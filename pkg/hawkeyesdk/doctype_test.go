@@ -0,0 +1,174 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseDocType_CodesNamesAndAliases(t *testing.T) {
+	t.Parallel()
+
+	for _, dt := range AllDocTypes() {
+		if got, err := ParseDocType(dt.Code()); err != nil || got != dt {
+			t.Fatalf("code %q parsed as %v, %v", dt.Code(), got, err)
+		}
+		if got, err := ParseDocType(dt.String()); err != nil || got != dt {
+			t.Fatalf("name %q parsed as %v, %v", dt.String(), got, err)
+		}
+	}
+
+	cases := map[string]DocType{
+		"POA":                       POA,
+		"aob":                       AOB,
+		"Vendor Invoice":            VENDOR_INVOICE,
+		"Vendor Inv":                VENDOR_INVOICE,
+		"check-in video":            CHECK_IN_VIDEO,
+		"Check-in Video (Drop-Off)": CHECK_IN_VIDEO,
+		"driver's license":          DRIVERS_LICENSE,
+		"  police report ":          POLICE_REPORT,
+		"40":                        POLICE_REPORT,
+	}
+	for input, expected := range cases {
+		if got, err := ParseDocType(input); err != nil || got != expected {
+			t.Fatalf("%q parsed as %v, %v; expected %v", input, got, err, expected)
+		}
+	}
+
+	if _, err := ParseDocType("Crystal Ball"); err == nil {
+		t.Fatalf("expected unknown doc type to fail")
+	}
+}
+
+func TestDocType_Codes(t *testing.T) {
+	t.Parallel()
+
+	if len(AllDocTypes()) != len(docTypeCodes) {
+		t.Fatalf("AllDocTypes and docTypeCodes disagree: %d vs %d", len(AllDocTypes()), len(docTypeCodes))
+	}
+	seen := map[string]bool{}
+	for _, dt := range AllDocTypes() {
+		code := dt.Code()
+		if code == "" || seen[code] {
+			t.Fatalf("missing or duplicate code for %v: %q", dt, code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestDocFile_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := DocFile{Doctype: POLICE_REPORT, Filename: "report.pdf"}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if string(data) != `{"doctype":"Police Report","dateadded":null,"user":"","filename":"report.pdf"}` {
+		t.Fatalf("unexpected json: %s", data)
+	}
+
+	var decoded DocFile
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if decoded != original {
+		t.Fatalf("round trip changed doc file: %+v", decoded)
+	}
+
+	var config struct {
+		Types map[DocType]bool `json:"types"`
+	}
+	if err := json.Unmarshal([]byte(`{"types":{"POA":true,"Vendor Invoice":true}}`), &config); err != nil {
+		t.Fatalf("text unmarshal failed: %v", err)
+	}
+	if !config.Types[POA] || !config.Types[VENDOR_INVOICE] {
+		t.Fatalf("unexpected map keys: %v", config.Types)
+	}
+}
+
+func TestDocType_UnmarshalJSON_UnknownIsDefault(t *testing.T) {
+	t.Parallel()
+
+	dt := IMAGES
+	if err := json.Unmarshal([]byte(`"Hologram"`), &dt); err != nil || dt != DEFAULT {
+		t.Fatalf("expected fallback to DEFAULT, got %v, %v", dt, err)
+	}
+	dt = IMAGES
+	if err := json.Unmarshal([]byte(`999`), &dt); err != nil || dt != DEFAULT {
+		t.Fatalf("expected fallback to DEFAULT, got %v, %v", dt, err)
+	}
+	if _, err := ParseDocType("Hologram"); err == nil {
+		t.Fatalf("expected ParseDocType to reject unknown type")
+	}
+}
+
+func TestClientSettings_StrictDocTypes(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"filenumber": 3, "docfiles": [{"doctype": "Police Report"}, {"doctype": "Hologram"}]}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	lenient := &ClientSettings{AuthToken: "token", BaseUrl: server.URL, HTTPClient: server.Client()}
+	docs, err := NewDocFilesService(lenient).ListDocFiles(context.Background(), 3)
+	if err != nil || len(docs) != 2 || docs[1].Doctype != DEFAULT {
+		t.Fatalf("expected the unknown type to decode as DEFAULT, got %+v, %v", docs, err)
+	}
+
+	strict := &ClientSettings{AuthToken: "token", BaseUrl: server.URL, HTTPClient: server.Client(), StrictDocTypes: true}
+	var decodeErr *DecodeError
+	if _, err := NewDocFilesService(strict).ListDocFiles(context.Background(), 3); !errors.As(err, &decodeErr) || !strings.Contains(err.Error(), "Hologram") {
+		t.Fatalf("expected a DecodeError naming the unknown type, got %v", err)
+	}
+
+	it := NewClaimsService(strict).IterateAdminClaims(context.Background())
+	defer it.Close()
+	for it.Next() {
+	}
+	if !errors.As(it.Err(), &decodeErr) {
+		t.Fatalf("expected the iterator to fail with a DecodeError, got %v", it.Err())
+	}
+}
+
+func TestDocFile_UnknownDoctypeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	stored := `[{"filenumber":3,"docfiles":[{"doctype":"Police Report","filename":"a.pdf"},{"doctype":"Hologram","filename":"b.pdf"},{"doctype":8,"filename":"c.pdf"}]}]`
+	var claims []AdminClaim
+	if err := json.Unmarshal([]byte(stored), &claims); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	docs := claims[0].DocFiles
+	if docs[0].Doctype != POLICE_REPORT || docs[0].RawDoctype() != "" || docs[2].Doctype != DocType(8) {
+		t.Fatalf("unexpected known types: %+v", docs)
+	}
+	if docs[1].Doctype != DEFAULT || docs[1].RawDoctype() != "Hologram" {
+		t.Fatalf("expected the unknown type to decode as DEFAULT keeping its text, got %+v", docs[1])
+	}
+
+	out, err := json.Marshal(docs[1])
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := `{"doctype":"Hologram","dateadded":null,"user":"","filename":"b.pdf"}`; string(out) != want {
+		t.Fatalf("expected %s, got %s", want, out)
+	}
+
+	// The strict policy applies to claims from your own store too.
+	if err := CheckDocTypes(&claims); err == nil || !strings.Contains(err.Error(), `"b.pdf"`) || !strings.Contains(err.Error(), "Hologram") {
+		t.Fatalf("expected the unknown type to be reported, got %v", err)
+	}
+	if err := CheckDocTypes(claims[0].DocFiles[:1]); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := CheckDocTypes(map[string]any{"claims": claims}); err == nil {
+		t.Fatal("expected documents behind an interface to be checked")
+	}
+}
@@ -3,42 +3,198 @@ package hawkeyesdk
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrNotFound     = errors.New("hawkeye: not found")
+	ErrUnauthorized = errors.New("hawkeye: unauthorized")
+	ErrForbidden    = errors.New("hawkeye: forbidden")
+	ErrRateLimited  = errors.New("hawkeye: rate limited")
+	ErrValidation   = errors.New("hawkeye: validation failed")
+	ErrDecode       = errors.New("hawkeye: failed to decode response")
+	ErrNoToken      = errors.New("hawkeye: no auth token")
+	ErrConfig       = errors.New("hawkeye: invalid configuration")
 )
 
 type APIError struct {
 	StatusCode int
 	Message    string
+
+	// Code is the server error code reported in ApiResponse.Error.
+	Code        int
+	Operation   string
+	URL         string
+	ContentType string
+	RequestID   string
+
+	// Body holds at most maxErrorBodySize bytes of the raw response.
+	Body          []byte
+	BodyTruncated bool
 }
 
 func (e *APIError) Error() string {
-	return fmt.Sprintf("Api returned status code %d: %s", e.StatusCode, e.Message)
+	msg := fmt.Sprintf("Api returned status code %d: %s", e.StatusCode, e.Message)
+	if e.Operation != "" {
+		msg = e.Operation + ": " + msg
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request id %s)", e.RequestID)
+	}
+	return msg
+}
+
+// Is matches the sentinel errors implied by the status code, so callers can
+// write errors.Is(err, ErrNotFound) for a 404.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// DecodeError reports a response body that could not be decoded.
+type DecodeError struct {
+	Operation string
+	URL       string
+
+	// Body holds at most maxErrorBodySize bytes of the raw response.
+	Body          []byte
+	BodyTruncated bool
+	Err           error
+}
+
+func (e *DecodeError) Error() string {
+	msg := fmt.Sprintf("failed to decode response: %v", e.Err)
+	if e.Operation != "" {
+		msg = e.Operation + ": " + msg
+	}
+	return msg
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
 }
 
-func checkResponse(resp *http.Response) error {
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+// sentinelError carries a human readable message while matching one of the
+// package sentinels through errors.Is.
+type sentinelError struct {
+	sentinel error
+	msg      string
+}
+
+func (e *sentinelError) Error() string {
+	return e.msg
+}
+
+func (e *sentinelError) Unwrap() error {
+	return e.sentinel
+}
+
+func newSentinelError(sentinel error, format string, args ...any) error {
+	return &sentinelError{sentinel: sentinel, msg: fmt.Sprintf(format, args...)}
+}
+
+const maxErrorBodySize = 4 << 10
+
+// requestIDHeaders are checked in order for an identifier that support can
+// use to trace a failed request through Hawkeye and any proxies in front of it.
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Amzn-Trace-Id", "Cf-Ray"}
+
+// checkResponse turns any non-2xx response into an *APIError, whatever the
+// body looks like: JSON, an HTML error page from a proxy, plain text or
+// nothing at all.
+func checkResponse(resp *http.Response, operation string) error {
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		return nil
 	}
 	defer resp.Body.Close()
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read error response body: %v", err)
+	apiErr := &APIError{
+		StatusCode:  resp.StatusCode,
+		Operation:   operation,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		apiErr.URL = resp.Request.URL.Redacted()
+	}
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			apiErr.RequestID = id
+			break
+		}
 	}
 
-	resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize+1))
+	if len(bodyBytes) > maxErrorBodySize {
+		bodyBytes = bodyBytes[:maxErrorBodySize]
+		apiErr.BodyTruncated = true
+	}
+	apiErr.Body = bodyBytes
+
+	resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 
 	var apiResp ApiResponse
+	if json.Unmarshal(bodyBytes, &apiResp) == nil {
+		apiErr.Message = apiResp.Message
+		apiErr.Code = apiResp.Error
+	} else {
+		apiErr.Message = errorBodySummary(apiErr.ContentType, bodyBytes)
+	}
 
-	err = json.Unmarshal(bodyBytes, &apiResp)
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+		apiErr.Message += fmt.Sprintf(" (failed to read error response body: %v)", err)
+	}
+
+	return apiErr
+}
+
+// errorBodySummary extracts a short message from a non-JSON error body: the
+// <title> of an HTML page or the first line of plain text.
+func errorBodySummary(contentType string, body []byte) string {
+	text := strings.TrimSpace(string(body))
+	if text == "" {
+		return ""
 	}
 
-	return &APIError{
-		StatusCode: resp.StatusCode,
-		Message:    apiResp.Message,
+	lower := strings.ToLower(text)
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "text/html" || strings.HasPrefix(lower, "<!doctype html") || strings.HasPrefix(lower, "<html") {
+		start := strings.Index(lower, "<title>")
+		end := strings.Index(lower, "</title>")
+		if start >= 0 && end > start {
+			return strings.TrimSpace(text[start+len("<title>") : end])
+		}
+		return ""
+	}
+
+	line, _, _ := strings.Cut(text, "\n")
+	line = strings.TrimSpace(line)
+	if !utf8.ValidString(line) {
+		return ""
+	}
+	if len(line) > 200 {
+		line = line[:200] + "..."
 	}
+	return line
 }
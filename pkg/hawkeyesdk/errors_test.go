@@ -0,0 +1,245 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIError_SentinelsByStatus(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		status   int
+		sentinel error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusTooManyRequests, ErrRateLimited},
+	}
+
+	for _, tc := range cases {
+		err := error(&APIError{StatusCode: tc.status})
+		if !errors.Is(err, tc.sentinel) {
+			t.Fatalf("expected status %d to match %v", tc.status, tc.sentinel)
+		}
+		if errors.Is(err, ErrDecode) {
+			t.Fatalf("status %d should not match ErrDecode", tc.status)
+		}
+	}
+}
+
+func TestClaimsService_GetSingleClaim_APIErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(ApiResponse{Message: "bad token", Error: 17})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	_, err := NewClaimsService(client).GetSingleClaim(context.Background(), 12)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Operation != "GetSingleClaim" || apiErr.Code != 17 || apiErr.Message != "bad token" {
+		t.Fatalf("unexpected api error: %+v", apiErr)
+	}
+	if !strings.HasSuffix(apiErr.URL, "/getclaims/12") {
+		t.Fatalf("unexpected url: %s", apiErr.URL)
+	}
+	if !strings.Contains(string(apiErr.Body), "bad token") {
+		t.Fatalf("expected raw body to be kept, got %q", apiErr.Body)
+	}
+}
+
+func TestClaimsService_GetSingleClaim_NotFoundSentinel(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	_, err := NewClaimsService(client).GetSingleClaim(context.Background(), 999)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Operation != "GetSingleClaim" || !strings.HasSuffix(apiErr.URL, "/getclaims/999") {
+		t.Fatalf("expected an *APIError with operation and URL, got %#v", err)
+	}
+}
+
+func TestAPIError_ForbiddenIsNotUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	err := error(&APIError{StatusCode: http.StatusForbidden})
+	if errors.Is(err, ErrUnauthorized) {
+		t.Fatal("expected 403 not to match ErrUnauthorized")
+	}
+	if errors.Is(&APIError{StatusCode: http.StatusUnauthorized}, ErrForbidden) {
+		t.Fatal("expected 401 not to match ErrForbidden")
+	}
+}
+
+func TestDecodeError_BodyIsCapped(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(strings.Repeat("x", maxErrorBodySize+100))
+	err := apiRequest{operation: "GetClaims", url: "https://example.com/getclaims"}.decodeError(body, errors.New("bad"))
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || len(decodeErr.Body) != maxErrorBodySize || !decodeErr.BodyTruncated {
+		t.Fatalf("expected the body to be capped at %d bytes, got %d", maxErrorBodySize, len(decodeErr.Body))
+	}
+}
+
+func TestClaimsService_GetClaims_DecodeError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"not":"an array"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	_, err := NewClaimsService(client).GetClaims(context.Background())
+	if !errors.Is(err, ErrDecode) {
+		t.Fatalf("expected ErrDecode, got %v", err)
+	}
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected *DecodeError, got %T", err)
+	}
+	if decodeErr.Operation != "GetClaims" || string(decodeErr.Body) != `{"not":"an array"}` {
+		t.Fatalf("unexpected decode error: %+v", decodeErr)
+	}
+}
+
+func TestClaimsService_CreateClaim_ValidationSentinel(t *testing.T) {
+	t.Parallel()
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    "http://example.com",
+		HTTPClient: http.DefaultClient,
+	}
+
+	_, err := NewClaimsService(client).CreateClaim(context.Background(), ClaimPost{})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestCheckResponse_NonJSONBodies(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		message     string
+	}{
+		{"html proxy page", http.StatusBadGateway, "text/html; charset=utf-8", "<html><head><title>502 Bad Gateway</title></head><body>nginx</body></html>", "502 Bad Gateway"},
+		{"empty body", http.StatusUnauthorized, "", "", "Unauthorized"},
+		{"plain text", http.StatusServiceUnavailable, "text/plain", "upstream unavailable\nretry later", "upstream unavailable"},
+		{"json", http.StatusBadRequest, "application/json", `{"message":"bad claim","error":3}`, "bad claim"},
+	}
+
+	for _, tc := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if tc.contentType != "" {
+				w.Header().Set("Content-Type", tc.contentType)
+			}
+			w.Header().Set("X-Request-Id", "req-123")
+			w.WriteHeader(tc.status)
+			w.Write([]byte(tc.body))
+		}))
+
+		client := &ClientSettings{
+			AuthToken:  "token",
+			BaseUrl:    server.URL,
+			HTTPClient: server.Client(),
+		}
+
+		_, err := NewLogTrailsService(client).CreateLogTrail(context.Background(), 1, "note")
+		server.Close()
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%s: expected *APIError, got %T: %v", tc.name, err, err)
+		}
+		if apiErr.StatusCode != tc.status || apiErr.Message != tc.message {
+			t.Fatalf("%s: unexpected api error: %+v", tc.name, apiErr)
+		}
+		if apiErr.RequestID != "req-123" {
+			t.Fatalf("%s: expected request id, got %q", tc.name, apiErr.RequestID)
+		}
+		if tc.contentType != "" && apiErr.ContentType != tc.contentType {
+			t.Fatalf("%s: unexpected content type %q", tc.name, apiErr.ContentType)
+		}
+		if string(apiErr.Body) != tc.body {
+			t.Fatalf("%s: unexpected body %q", tc.name, apiErr.Body)
+		}
+	}
+}
+
+func TestCheckResponse_TruncatesLargeBodies(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(strings.Repeat("x", maxErrorBodySize*3)))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	_, err := NewClaimsService(client).GetClaims(context.Background())
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if len(apiErr.Body) != maxErrorBodySize || !apiErr.BodyTruncated {
+		t.Fatalf("expected body truncated to %d bytes, got %d (truncated=%v)", maxErrorBodySize, len(apiErr.Body), apiErr.BodyTruncated)
+	}
+	if len(apiErr.Message) > 210 {
+		t.Fatalf("expected short message, got %d bytes", len(apiErr.Message))
+	}
+}
@@ -0,0 +1,423 @@
+package hawkeyesdk
+
+import (
+	"bufio"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type ExportFormat int
+
+const (
+	ExportCSV ExportFormat = iota
+	// ExportJSONLines writes one JSON object per line, with keys in column
+	// order.
+	ExportJSONLines
+)
+
+// ExportColumns lists the columns available for T in struct order: the JSON
+// field names from models.go, without the nested docfiles and logtrail.
+func ExportColumns[T ClaimRecord]() []string {
+	fields := exportFieldsOf(reflect.TypeFor[T]())
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if !isNestedColumn(f.name) {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+func isNestedColumn(name string) bool {
+	return name == "docfiles" || name == "logtrail"
+}
+
+type exportField struct {
+	name  string
+	index int
+}
+
+func exportFieldsOf(t reflect.Type) []exportField {
+	var fields []exportField
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || !t.Field(i).IsExported() {
+			continue
+		}
+		fields = append(fields, exportField{name: name, index: i})
+	}
+	return fields
+}
+
+type ExportOption func(*exportOptions)
+
+type exportOptions struct {
+	columns    []string
+	money      func(Money) string
+	dateLayout string
+	inline     bool
+	rawText    bool
+	docFiles   io.Writer
+	logTrail   io.Writer
+}
+
+// WithExportColumns selects and orders the exported columns by JSON field
+// name, as listed by ExportColumns. By default every column is exported.
+func WithExportColumns(columns ...string) ExportOption {
+	return func(opts *exportOptions) {
+		opts.columns = columns
+	}
+}
+
+// WithMoneyFormat formats amounts, for example with Money.String for
+// "$1,234.56". By default amounts are plain decimals such as "1234.56", and
+// JSON Lines writes them as numbers.
+func WithMoneyFormat(format func(Money) string) ExportOption {
+	return func(opts *exportOptions) {
+		opts.money = format
+	}
+}
+
+// WithExportDateLayout formats dates, including those in child tables, with
+// layout instead of DateLayoutISO. Unset dates are written as "" and dates in
+// an unknown format as received.
+func WithExportDateLayout(layout string) ExportOption {
+	return func(opts *exportOptions) {
+		opts.dateLayout = layout
+	}
+}
+
+// WithFormulaEscaping controls whether CSV text that starts with =, +, -, @,
+// a tab or a carriage return is prefixed with a single quote, so a spreadsheet shows a renter name or
+// note like "=HYPERLINK(...)" instead of running it. It is on by default and
+// does not apply to numbers, amounts or JSON Lines.
+func WithFormulaEscaping(escape bool) ExportOption {
+	return func(opts *exportOptions) {
+		opts.rawText = !escape
+	}
+}
+
+// WithInlineNested adds docfiles and logtrail columns holding each claim's
+// documents and log trail as JSON arrays.
+func WithInlineNested() ExportOption {
+	return func(opts *exportOptions) {
+		opts.inline = true
+	}
+}
+
+// WithChildTables writes documents and log trail entries to separate tables
+// in the same format, one row per item with the claim's filenumber first.
+// Either writer may be nil to skip that table.
+func WithChildTables(docFiles, logTrail io.Writer) ExportOption {
+	return func(opts *exportOptions) {
+		opts.docFiles = docFiles
+		opts.logTrail = logTrail
+	}
+}
+
+// ClaimExporter writes claims as CSV or JSON Lines, one row per claim. Call
+// Flush when done; rows are buffered.
+type ClaimExporter[T ClaimRecord] struct {
+	format   exportFormatter
+	columns  []exportField
+	main     *exportTable
+	docFiles *exportTable
+	logTrail *exportTable
+}
+
+// NewClaimExporter writes the header, for CSV, and returns an exporter. It
+// fails with ErrConfig when a selected column does not exist.
+func NewClaimExporter[T ClaimRecord](w io.Writer, format ExportFormat, opts ...ExportOption) (*ClaimExporter[T], error) {
+	options := exportOptions{dateLayout: DateLayoutISO}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	available := exportFieldsOf(reflect.TypeFor[T]())
+	var columns []exportField
+	if len(options.columns) == 0 {
+		for _, f := range available {
+			if !isNestedColumn(f.name) || options.inline {
+				columns = append(columns, f)
+			}
+		}
+	} else {
+		byName := make(map[string]exportField, len(available))
+		for _, f := range available {
+			byName[f.name] = f
+		}
+		for _, name := range options.columns {
+			f, ok := byName[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				return nil, newSentinelError(ErrConfig, "unknown export column %q", name)
+			}
+			columns = append(columns, f)
+		}
+	}
+
+	e := &ClaimExporter[T]{
+		format:  exportFormatter{money: options.money, dateLayout: options.dateLayout},
+		columns: columns,
+	}
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.name
+	}
+	escape := format == ExportCSV && !options.rawText
+	e.main = newExportTable(w, format, header, escape)
+	if options.docFiles != nil {
+		e.docFiles = newExportTable(options.docFiles, format, childHeader(reflect.TypeFor[DocFile]()), escape)
+	}
+	if options.logTrail != nil {
+		e.logTrail = newExportTable(options.logTrail, format, childHeader(reflect.TypeFor[LogTrail]()), escape)
+	}
+	return e, nil
+}
+
+func childHeader(t reflect.Type) []string {
+	header := []string{"filenumber"}
+	for _, f := range exportFieldsOf(t) {
+		header = append(header, f.name)
+	}
+	return header
+}
+
+// Write exports one claim and, with child tables, its documents and log
+// trail entries.
+func (e *ClaimExporter[T]) Write(claim T) error {
+	v := reflect.ValueOf(claim)
+	row := make([]exportCell, len(e.columns))
+	for i, c := range e.columns {
+		row[i] = e.format.cell(v.Field(c.index))
+	}
+	if err := e.main.write(row); err != nil {
+		return err
+	}
+
+	view := viewOf(&claim)
+	if e.docFiles != nil {
+		if err := e.writeChildren(e.docFiles, view.filenumber, reflect.ValueOf(view.docFiles)); err != nil {
+			return err
+		}
+	}
+	if e.logTrail != nil {
+		if err := e.writeChildren(e.logTrail, view.filenumber, reflect.ValueOf(view.logTrail)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *ClaimExporter[T]) writeChildren(table *exportTable, filenumber int, items reflect.Value) error {
+	fields := exportFieldsOf(items.Type().Elem())
+	for i := 0; i < items.Len(); i++ {
+		item := items.Index(i)
+		row := make([]exportCell, 0, len(fields)+1)
+		row = append(row, e.format.cell(reflect.ValueOf(filenumber)))
+		for _, f := range fields {
+			row = append(row, e.format.cell(item.Field(f.index)))
+		}
+		if err := table.write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteAll exports claims in order.
+func (e *ClaimExporter[T]) WriteAll(claims []T) error {
+	for _, claim := range claims {
+		if err := e.Write(claim); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteIterator exports every claim produced by it and returns how many
+// were written. It does not close it.
+func (e *ClaimExporter[T]) WriteIterator(it *Iterator[T]) (int, error) {
+	n := 0
+	for it.Next() {
+		if err := e.Write(it.Value()); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, it.Err()
+}
+
+// Flush writes any buffered rows to every table.
+func (e *ClaimExporter[T]) Flush() error {
+	for _, table := range []*exportTable{e.main, e.docFiles, e.logTrail} {
+		if table == nil {
+			continue
+		}
+		if err := table.flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteClaimsCSV writes claims to w as CSV with a header row.
+func WriteClaimsCSV[T ClaimRecord](w io.Writer, claims []T, opts ...ExportOption) error {
+	return export(w, ExportCSV, claims, opts)
+}
+
+// WriteClaimsJSONLines writes claims to w as JSON Lines.
+func WriteClaimsJSONLines[T ClaimRecord](w io.Writer, claims []T, opts ...ExportOption) error {
+	return export(w, ExportJSONLines, claims, opts)
+}
+
+func export[T ClaimRecord](w io.Writer, format ExportFormat, claims []T, opts []ExportOption) error {
+	e, err := NewClaimExporter[T](w, format, opts...)
+	if err != nil {
+		return err
+	}
+	if err := e.WriteAll(claims); err != nil {
+		return err
+	}
+	return e.Flush()
+}
+
+// exportCell is one value as CSV text and as a JSON value. Free text is
+// escaped against formula injection; formatted numbers and dates are not.
+type exportCell struct {
+	text     string
+	json     []byte
+	freeText bool
+}
+
+type exportFormatter struct {
+	money      func(Money) string
+	dateLayout string
+}
+
+func (f exportFormatter) cell(v reflect.Value) exportCell {
+	switch x := v.Interface().(type) {
+	case Money:
+		if f.money == nil {
+			return exportCell{text: x.Decimal(), json: []byte(x.Decimal())}
+		}
+		return stringCell(f.money(x))
+	case Date:
+		if x.Time.IsZero() {
+			return stringCell(x.Raw())
+		}
+		return stringCell(x.Format(f.dateLayout))
+	case string:
+		return textCell(x)
+	case *string:
+		if x == nil {
+			return exportCell{json: []byte("null")}
+		}
+		return textCell(*x)
+	case bool:
+		text := strconv.FormatBool(x)
+		return exportCell{text: text, json: []byte(text)}
+	case int:
+		text := strconv.Itoa(x)
+		return exportCell{text: text, json: []byte(text)}
+	case float32:
+		text := strconv.FormatFloat(float64(x), 'f', -1, 32)
+		return exportCell{text: text, json: []byte(text)}
+	case encoding.TextMarshaler:
+		text, _ := x.MarshalText()
+		return textCell(string(text))
+	}
+
+	if v.Kind() == reflect.Slice && v.Len() == 0 {
+		return exportCell{json: []byte("[]")}
+	}
+	data, _ := json.Marshal(v.Interface())
+	return exportCell{text: string(data), json: data}
+}
+
+func stringCell(s string) exportCell {
+	data, _ := json.Marshal(s)
+	return exportCell{text: s, json: data}
+}
+
+func textCell(s string) exportCell {
+	c := stringCell(s)
+	c.freeText = true
+	return c
+}
+
+// escapeFormula keeps spreadsheets from evaluating s as a formula.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// exportTable writes rows of cells in one format.
+type exportTable struct {
+	csv    *csv.Writer
+	buf    *bufio.Writer
+	keys   [][]byte
+	escape bool
+	err    error
+}
+
+func newExportTable(w io.Writer, format ExportFormat, header []string, escape bool) *exportTable {
+	if format == ExportJSONLines {
+		keys := make([][]byte, len(header))
+		for i, name := range header {
+			keys[i], _ = json.Marshal(name)
+		}
+		return &exportTable{buf: bufio.NewWriter(w), keys: keys}
+	}
+	t := &exportTable{csv: csv.NewWriter(w), escape: escape}
+	t.err = t.csv.Write(header)
+	return t
+}
+
+func (t *exportTable) write(row []exportCell) error {
+	if t.err != nil {
+		return t.err
+	}
+	if t.csv != nil {
+		record := make([]string, len(row))
+		for i, c := range row {
+			record[i] = c.text
+			if t.escape && c.freeText {
+				record[i] = escapeFormula(c.text)
+			}
+		}
+		t.err = t.csv.Write(record)
+		return t.err
+	}
+
+	t.buf.WriteByte('{')
+	for i, c := range row {
+		if i > 0 {
+			t.buf.WriteByte(',')
+		}
+		t.buf.Write(t.keys[i])
+		t.buf.WriteByte(':')
+		t.buf.Write(c.json)
+	}
+	_, t.err = t.buf.WriteString("}\n")
+	return t.err
+}
+
+func (t *exportTable) flush() error {
+	if t.err != nil {
+		return t.err
+	}
+	if t.csv != nil {
+		t.csv.Flush()
+		t.err = t.csv.Error()
+	} else {
+		t.err = t.buf.Flush()
+	}
+	return t.err
+}
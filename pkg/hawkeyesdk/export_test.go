@@ -0,0 +1,258 @@
+package hawkeyesdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testExportClaims(t *testing.T) []AdminClaim {
+	t.Helper()
+
+	notes := "front bumper"
+	return []AdminClaim{
+		{
+			Filenumber:       101,
+			RenterName:       "Ada Lovelace",
+			DateOfLoss:       NewDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+			EstimateAmount:   Cents(123456),
+			TotalLoss:        true,
+			InsuranceCompany: "Acme, Mutual",
+			DocFiles: []DocFile{
+				{Doctype: POLICE_REPORT, Filename: "police.pdf", DateAdded: mustParseDate(t, "2024-03-02"), Notes: &notes},
+				{Doctype: IMAGES, Filename: "photo.jpg"},
+			},
+			LogTrail: []LogTrail{{Date: mustParseDate(t, "03/02/2024"), Activity: "Claim opened", User: "ops"}},
+		},
+		{Filenumber: 102, RenterName: "Grace Hopper", EstimateAmount: Cents(-500)},
+	}
+}
+
+func TestWriteClaimsCSV_Columns(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := WriteClaimsCSV(&buf, testExportClaims(t),
+		WithExportColumns("filenumber", "rentername", "insurancecompany", "dateofloss", "estimateamount", "totalloss"),
+		WithExportDateLayout(DateLayoutUS),
+		WithMoneyFormat(Money.String),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := "filenumber,rentername,insurancecompany,dateofloss,estimateamount,totalloss\n" +
+		"101,Ada Lovelace,\"Acme, Mutual\",03/01/2024,\"$1,234.56\",true\n" +
+		"102,Grace Hopper,,,-$5.00,false\n"
+	if buf.String() != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, buf.String())
+	}
+
+	if err := WriteClaimsCSV(&buf, testExportClaims(t), WithExportColumns("colour")); !errors.Is(err, ErrConfig) {
+		t.Fatalf("expected ErrConfig for an unknown column, got %v", err)
+	}
+}
+
+func TestWriteClaimsCSV_AllColumnsMatchModel(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := WriteClaimsCSV(&buf, testExportClaims(t)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	columns := ExportColumns[AdminClaim]()
+	if !reflect.DeepEqual(records[0], columns) {
+		t.Fatalf("expected the header to match ExportColumns")
+	}
+	// Every JSON field except the nested slices is a column.
+	if want := reflect.TypeFor[AdminClaim]().NumField() - 2; len(columns) != want {
+		t.Fatalf("expected %d columns, got %d", want, len(columns))
+	}
+	if len(records) != 3 || len(records[1]) != len(columns) {
+		t.Fatalf("unexpected records: %d rows", len(records))
+	}
+	if ExportColumns[Claim]()[0] != "filenumber" {
+		t.Fatalf("expected Claim columns in struct order, got %v", ExportColumns[Claim]()[:3])
+	}
+}
+
+func TestWriteClaimsJSONLines_Inline(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := WriteClaimsJSONLines(&buf, testExportClaims(t),
+		WithExportColumns("filenumber", "estimateamount", "dateofloss", "docfiles"),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	want := `{"filenumber":101,"estimateamount":1234.56,"dateofloss":"2024-03-01","docfiles":[` +
+		`{"doctype":"Police Report","dateadded":"2024-03-02","user":"","notes":"front bumper","filename":"police.pdf"},` +
+		`{"doctype":"Images","dateadded":null,"user":"","filename":"photo.jpg"}]}`
+	if len(lines) != 2 || lines[0] != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, buf.String())
+	}
+	if lines[1] != `{"filenumber":102,"estimateamount":-5.00,"dateofloss":"","docfiles":[]}` {
+		t.Fatalf("unexpected second line %s", lines[1])
+	}
+
+	var claim AdminClaim
+	if err := json.Unmarshal([]byte(lines[0]), &claim); err != nil || len(claim.DocFiles) != 2 || claim.EstimateAmount != Cents(123456) {
+		t.Fatalf("expected the line to decode as an AdminClaim, got %+v, %v", claim, err)
+	}
+}
+
+func TestClaimExporter_ChildTables(t *testing.T) {
+	t.Parallel()
+
+	var claims, docs, logs bytes.Buffer
+	e, err := NewClaimExporter[AdminClaim](&claims, ExportCSV,
+		WithExportColumns("filenumber", "rentername"),
+		WithChildTables(&docs, &logs),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := e.WriteAll(testExportClaims(t)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if want := "filenumber,rentername\n101,Ada Lovelace\n102,Grace Hopper\n"; claims.String() != want {
+		t.Fatalf("expected %q, got %q", want, claims.String())
+	}
+	wantDocs := "filenumber,doctype,dateadded,user,notes,filename\n" +
+		"101,Police Report,2024-03-02,,front bumper,police.pdf\n" +
+		"101,Images,,,,photo.jpg\n"
+	if docs.String() != wantDocs {
+		t.Fatalf("expected %q, got %q", wantDocs, docs.String())
+	}
+	// Child table dates use the export layout too.
+	if want := "filenumber,date,activity,user\n101,2024-03-02,Claim opened,ops\n"; logs.String() != want {
+		t.Fatalf("expected %q, got %q", want, logs.String())
+	}
+}
+
+func TestClaimExporter_WriteIterator(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"filenumber": 1, "rentername": "A"}, {"filenumber": 2, "rentername": "B"}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{AuthToken: "test-token", BaseUrl: server.URL, HTTPClient: server.Client()}
+	it := NewClaimsService(client).IterateClaims(context.Background())
+	defer it.Close()
+
+	var buf bytes.Buffer
+	e, err := NewClaimExporter[Claim](&buf, ExportJSONLines, WithExportColumns("filenumber", "rentername"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	n, err := e.WriteIterator(it)
+	if err != nil || n != 2 {
+		t.Fatalf("expected 2 claims, got %d, %v", n, err)
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := "{\"filenumber\":1,\"rentername\":\"A\"}\n{\"filenumber\":2,\"rentername\":\"B\"}\n"; buf.String() != want {
+		t.Fatalf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestWriteClaimsCSV_FormulaEscaping(t *testing.T) {
+	t.Parallel()
+
+	notes := "@SUM(A1:A9)"
+	claims := []AdminClaim{{
+		Filenumber:       101,
+		RenterName:       "=HYPERLINK(\"http://x\")",
+		InsuranceCompany: "+Acme",
+		ClientClaimNo:    "-ABC",
+		ClaimNumber:      "\t=1+2",
+		PolicyNumber:     "\r=1+2",
+		EstimateAmount:   Cents(-500),
+		DocFiles:         []DocFile{{Doctype: IMAGES, Filename: "photo.jpg", Notes: &notes}},
+	}}
+	columns := WithExportColumns("rentername", "insurancecompany", "clientclaimno", "estimateamount")
+
+	var buf, docs bytes.Buffer
+	if err := WriteClaimsCSV(&buf, claims, columns, WithMoneyFormat(Money.String), WithChildTables(&docs, nil)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var tabs bytes.Buffer
+	if err := WriteClaimsCSV(&tabs, claims, WithExportColumns("claimnumber", "policynumber")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := "claimnumber,policynumber\n'\t=1+2,\"'\r=1+2\"\n"; tabs.String() != want {
+		t.Fatalf("expected a leading tab or carriage return to be escaped, got %q", tabs.String())
+	}
+
+	// Amounts stay numbers; only free text is escaped.
+	want := "rentername,insurancecompany,clientclaimno,estimateamount\n" +
+		"\"'=HYPERLINK(\"\"http://x\"\")\",'+Acme,'-ABC,-$5.00\n"
+	if buf.String() != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, buf.String())
+	}
+	if want := "101,Images,,,'@SUM(A1:A9),photo.jpg\n"; !strings.HasSuffix(docs.String(), want) {
+		t.Fatalf("expected child tables to be escaped, got %q", docs.String())
+	}
+
+	buf.Reset()
+	if err := WriteClaimsCSV(&buf, claims, columns, WithFormulaEscaping(false)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "\n\"=HYPERLINK(\"\"http://x\"\")\",+Acme,-ABC,-5.00\n") {
+		t.Fatalf("expected raw text with escaping off, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteClaimsJSONLines(&buf, claims, WithExportColumns("rentername")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := "{\"rentername\":\"=HYPERLINK(\\\"http://x\\\")\"}\n"; buf.String() != want {
+		t.Fatalf("expected JSON Lines to be unescaped, got %q", buf.String())
+	}
+}
+
+func TestClaimExporter_ChildTablesDateLayout(t *testing.T) {
+	t.Parallel()
+
+	var claims, docs, logs bytes.Buffer
+	err := WriteClaimsCSV(&claims, testExportClaims(t),
+		WithExportColumns("filenumber", "dateofloss"),
+		WithExportDateLayout(DateLayoutUS),
+		WithChildTables(&docs, &logs),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := "filenumber,dateofloss\n101,03/01/2024\n102,\n"; claims.String() != want {
+		t.Fatalf("expected %q, got %q", want, claims.String())
+	}
+	if want := "101,Police Report,03/02/2024,,front bumper,police.pdf\n"; !strings.Contains(docs.String(), want) {
+		t.Fatalf("expected document dates in the export layout, got %q", docs.String())
+	}
+	if want := "filenumber,date,activity,user\n101,03/02/2024,Claim opened,ops\n"; logs.String() != want {
+		t.Fatalf("expected log trail dates in the export layout, got %q", logs.String())
+	}
+}
@@ -0,0 +1,181 @@
+package hawkeyesdk
+
+// Financial summary categories, in the order they appear in
+// FinancialSummary.Lines.
+const (
+	FinancialPropertyDamage  = "Property Damage"
+	FinancialLossOfUse       = "Loss of Use"
+	FinancialDiminishedValue = "Diminished Value"
+	FinancialTowing          = "Towing"
+	FinancialStorage         = "Storage"
+	FinancialTotalLoss       = "Total Loss"
+	FinancialDemandFees      = "Demand Fees"
+	FinancialOther           = "Other"
+)
+
+// FinancialLine is the demanded and settled amount of one category.
+// Outstanding is what was demanded but not yet settled; in a combined
+// summary it is the sum of each claim's own outstanding amount.
+type FinancialLine struct {
+	Category    string
+	Demanded    Money
+	Settled     Money
+	Outstanding Money
+}
+
+// FinancialSummary totals the money fields of a claim.
+//
+// Demanded is the sum of the demanded side of every line: the estimate plus
+// supplements, loss of use, diminished value, towing, storage, ACV for total
+// losses and the demand admin and appraisal fees. Settled is the sum of the
+// settlement components; NetSettled subtracts the salvage and deductible
+// settlements. Outstanding is what was demanded but not yet recovered, and
+// ReserveVariance compares it with the reserves (positive when the claim is
+// over-reserved). In a summary combined with Add, Outstanding and
+// ReserveVariance are the sums of each claim's own figures, so an
+// over-recovered claim does not offset what is still owed on another.
+type FinancialSummary struct {
+	Lines []FinancialLine
+
+	Demanded        Money
+	Settled         Money
+	Salvage         Money
+	Deductible      Money
+	NetSettled      Money
+	Outstanding     Money
+	Reserves        Money
+	ReserveVariance Money
+	InvoicedFees    Money
+	DemandFees      Money
+
+	// RecoveryRatio is NetSettled / Demanded, or 0 when nothing was demanded.
+	RecoveryRatio float64
+}
+
+// FinancialSummary computes the summary of a claim. Claim has no reserve,
+// towing, storage or ACV fields, so those count as zero.
+func (c *Claim) FinancialSummary() FinancialSummary {
+	return newFinancialSummary([]FinancialLine{
+		{Category: FinancialPropertyDamage, Demanded: c.EstimateAmount.Add(c.Supplement), Settled: c.SettlementPD},
+		{Category: FinancialLossOfUse, Demanded: c.ContinuedRentalAmt, Settled: c.SettlementCR},
+		{Category: FinancialDiminishedValue, Demanded: c.DVAmt, Settled: c.SettlementDV},
+		{Category: FinancialTowing, Settled: c.SettlementTowing},
+		{Category: FinancialStorage, Settled: c.SettlementStorage},
+		{Category: FinancialTotalLoss},
+		{Category: FinancialDemandFees, Demanded: c.DemandAdminFee.Add(c.DemandAppraisalFee)},
+		{Category: FinancialOther, Settled: c.SettlementOther},
+	}, financialAdjustments{
+		salvage:      c.SettlementSalvage,
+		deductible:   c.SettlementDeductible,
+		invoicedFees: c.AdministrativeFee.Add(c.AppraisalFee),
+	})
+}
+
+// FinancialSummary computes the summary of an admin claim, including its
+// reserves.
+func (a *AdminClaim) FinancialSummary() FinancialSummary {
+	return newFinancialSummary([]FinancialLine{
+		{Category: FinancialPropertyDamage, Demanded: a.EstimateAmount.Add(a.Supplement), Settled: a.SettlementPD},
+		{Category: FinancialLossOfUse, Demanded: a.LossOfUseAmount, Settled: a.SettlementLOU},
+		{Category: FinancialDiminishedValue, Demanded: a.DVAmount, Settled: a.SettlementDV},
+		{Category: FinancialTowing, Demanded: a.Towing, Settled: a.SettlementTowing},
+		{Category: FinancialStorage, Demanded: a.Storage, Settled: a.SettlementStorage},
+		{Category: FinancialTotalLoss, Demanded: a.ACV, Settled: a.SettlementTotalLoss},
+		{Category: FinancialDemandFees, Demanded: a.DemandAdminFee.Add(a.DemandAppraisalFee)},
+		{Category: FinancialOther, Settled: a.SettlementOther},
+	}, financialAdjustments{
+		salvage:      a.SettlementSalvage,
+		deductible:   a.SettlementDeductible,
+		invoicedFees: a.AdministrativeFee.Add(a.AppraisalFee),
+		reserves:     Sum(a.ReserveAmount, a.ReserveAmount2, a.ReserveAmount3, a.ReserveAmount4),
+	})
+}
+
+type financialAdjustments struct {
+	salvage      Money
+	deductible   Money
+	invoicedFees Money
+	reserves     Money
+}
+
+func newFinancialSummary(lines []FinancialLine, adj financialAdjustments) FinancialSummary {
+	s := FinancialSummary{
+		Lines:        lines,
+		Salvage:      adj.salvage,
+		Deductible:   adj.deductible,
+		InvoicedFees: adj.invoicedFees,
+		Reserves:     adj.reserves,
+	}
+	for i, line := range lines {
+		lines[i].Outstanding = outstanding(line.Demanded, line.Settled)
+		s.Demanded = s.Demanded.Add(line.Demanded)
+		s.Settled = s.Settled.Add(line.Settled)
+		if line.Category == FinancialDemandFees {
+			s.DemandFees = s.DemandFees.Add(line.Demanded)
+		}
+	}
+	s.finish()
+	s.Outstanding = outstanding(s.Demanded, s.NetSettled)
+	s.ReserveVariance = s.Reserves.Sub(s.Outstanding)
+	return s
+}
+
+// outstanding is what was demanded but not recovered, never negative.
+func outstanding(demanded, recovered Money) Money {
+	return Cents(max(demanded.Sub(recovered).Cents(), 0))
+}
+
+// finish derives the net and ratio figures from the totals.
+func (s *FinancialSummary) finish() {
+	s.NetSettled = s.Settled.Sub(s.Salvage).Sub(s.Deductible)
+	s.RecoveryRatio = 0
+	if !s.Demanded.IsZero() {
+		s.RecoveryRatio = s.NetSettled.Float64() / s.Demanded.Float64()
+	}
+}
+
+// Add combines two summaries, e.g. to total a portfolio of claims. Lines are
+// merged by category. Outstanding amounts and reserve variances are summed
+// rather than derived from the combined totals.
+func (s FinancialSummary) Add(other FinancialSummary) FinancialSummary {
+	total := FinancialSummary{
+		Demanded:     s.Demanded.Add(other.Demanded),
+		Settled:      s.Settled.Add(other.Settled),
+		Salvage:      s.Salvage.Add(other.Salvage),
+		Deductible:   s.Deductible.Add(other.Deductible),
+		Reserves:     s.Reserves.Add(other.Reserves),
+		InvoicedFees: s.InvoicedFees.Add(other.InvoicedFees),
+		DemandFees:   s.DemandFees.Add(other.DemandFees),
+
+		Outstanding:     s.Outstanding.Add(other.Outstanding),
+		ReserveVariance: s.ReserveVariance.Add(other.ReserveVariance),
+	}
+
+	index := make(map[string]int)
+	for _, lines := range [][]FinancialLine{s.Lines, other.Lines} {
+		for _, line := range lines {
+			i, ok := index[line.Category]
+			if !ok {
+				i = len(total.Lines)
+				index[line.Category] = i
+				total.Lines = append(total.Lines, FinancialLine{Category: line.Category})
+			}
+			total.Lines[i].Demanded = total.Lines[i].Demanded.Add(line.Demanded)
+			total.Lines[i].Settled = total.Lines[i].Settled.Add(line.Settled)
+			total.Lines[i].Outstanding = total.Lines[i].Outstanding.Add(line.Outstanding)
+		}
+	}
+
+	total.finish()
+	return total
+}
+
+// Line returns the line for category, or a zero line if there is none.
+func (s FinancialSummary) Line(category string) FinancialLine {
+	for _, line := range s.Lines {
+		if line.Category == category {
+			return line
+		}
+	}
+	return FinancialLine{Category: category}
+}
@@ -0,0 +1,133 @@
+package hawkeyesdk
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAdminClaim_FinancialSummary(t *testing.T) {
+	t.Parallel()
+
+	claim := &AdminClaim{
+		EstimateAmount:       Dollars(8000),
+		Supplement:           Dollars(500),
+		LossOfUseAmount:      Dollars(1200),
+		DVAmount:             Dollars(1000),
+		Towing:               Dollars(250),
+		Storage:              Dollars(300),
+		DemandAdminFee:       Dollars(150),
+		DemandAppraisalFee:   Dollars(100),
+		SettlementPD:         Dollars(8000),
+		SettlementLOU:        Dollars(900),
+		SettlementDV:         Dollars(600),
+		SettlementTowing:     Dollars(250),
+		SettlementStorage:    Dollars(150),
+		SettlementOther:      Cents(4999),
+		SettlementSalvage:    Dollars(100),
+		SettlementDeductible: Dollars(500),
+		ReserveAmount:        Dollars(2000),
+		ReserveAmount3:       Dollars(500),
+		AdministrativeFee:    Dollars(75),
+		AppraisalFee:         Dollars(125),
+	}
+
+	s := claim.FinancialSummary()
+
+	checks := map[string][2]Money{
+		"demanded":         {s.Demanded, Dollars(11500)},
+		"settled":          {s.Settled, Cents(994999)},
+		"net settled":      {s.NetSettled, Cents(934999)},
+		"outstanding":      {s.Outstanding, Cents(215001)},
+		"reserves":         {s.Reserves, Dollars(2500)},
+		"reserve variance": {s.ReserveVariance, Cents(34999)},
+		"invoiced fees":    {s.InvoicedFees, Dollars(200)},
+		"demand fees":      {s.DemandFees, Dollars(250)},
+	}
+	for name, c := range checks {
+		if c[0] != c[1] {
+			t.Fatalf("%s: got %s, expected %s", name, c[0], c[1])
+		}
+	}
+
+	if want := 934999.0 / 1150000.0; math.Abs(s.RecoveryRatio-want) > 1e-12 {
+		t.Fatalf("unexpected recovery ratio %v, expected %v", s.RecoveryRatio, want)
+	}
+	if line := s.Line(FinancialLossOfUse); line.Demanded != Dollars(1200) || line.Settled != Dollars(900) {
+		t.Fatalf("unexpected loss of use line: %+v", line)
+	}
+}
+
+func TestClaim_FinancialSummary(t *testing.T) {
+	t.Parallel()
+
+	claim := &Claim{
+		EstimateAmount:     Dollars(1000),
+		ContinuedRentalAmt: Dollars(300),
+		SettlementPD:       Dollars(1000),
+		SettlementCR:       Dollars(300),
+		SettlementOther:    Dollars(50),
+	}
+
+	s := claim.FinancialSummary()
+	if s.Demanded != Dollars(1300) || s.NetSettled != Dollars(1350) {
+		t.Fatalf("unexpected totals: %+v", s)
+	}
+	if !s.Outstanding.IsZero() {
+		t.Fatalf("expected over-recovery to leave nothing outstanding, got %s", s.Outstanding)
+	}
+	if !s.Reserves.IsZero() || !s.ReserveVariance.IsZero() {
+		t.Fatalf("expected no reserves on Claim, got %+v", s)
+	}
+
+	if empty := (&Claim{}).FinancialSummary(); empty.RecoveryRatio != 0 {
+		t.Fatalf("expected zero ratio when nothing was demanded, got %v", empty.RecoveryRatio)
+	}
+}
+
+func TestFinancialSummary_Add(t *testing.T) {
+	t.Parallel()
+
+	a := (&AdminClaim{EstimateAmount: Dollars(100), SettlementPD: Dollars(50), ReserveAmount: Dollars(60)}).FinancialSummary()
+	b := (&AdminClaim{EstimateAmount: Dollars(300), SettlementPD: Dollars(250), DVAmount: Dollars(100)}).FinancialSummary()
+
+	total := a.Add(b)
+	if total.Demanded != Dollars(500) || total.NetSettled != Dollars(300) || total.Outstanding != Dollars(200) {
+		t.Fatalf("unexpected totals: %+v", total)
+	}
+	if total.ReserveVariance != Dollars(-140) {
+		t.Fatalf("unexpected reserve variance: %s", total.ReserveVariance)
+	}
+	if total.RecoveryRatio != 0.6 {
+		t.Fatalf("unexpected ratio: %v", total.RecoveryRatio)
+	}
+	if len(total.Lines) != len(a.Lines) || total.Line(FinancialPropertyDamage).Demanded != Dollars(400) {
+		t.Fatalf("unexpected merged lines: %+v", total.Lines)
+	}
+
+	var portfolio FinancialSummary
+	portfolio = portfolio.Add(a)
+	if portfolio.Demanded != a.Demanded || len(portfolio.Lines) != len(a.Lines) {
+		t.Fatalf("expected adding to an empty summary to copy it: %+v", portfolio)
+	}
+}
+
+func TestFinancialSummary_AddOverRecovered(t *testing.T) {
+	t.Parallel()
+
+	a := (&AdminClaim{EstimateAmount: Dollars(100), SettlementPD: Dollars(150), ReserveAmount: Dollars(20)}).FinancialSummary()
+	b := (&AdminClaim{EstimateAmount: Dollars(100), ReserveAmount: Dollars(30)}).FinancialSummary()
+
+	total := a.Add(b)
+	if total.Demanded != Dollars(200) || total.NetSettled != Dollars(150) {
+		t.Fatalf("unexpected totals: %+v", total)
+	}
+	if total.Outstanding != Dollars(100) {
+		t.Fatalf("expected the over-recovery on one claim not to offset another, got %s", total.Outstanding)
+	}
+	if total.ReserveVariance != Dollars(-50) {
+		t.Fatalf("unexpected reserve variance: %s", total.ReserveVariance)
+	}
+	if line := total.Line(FinancialPropertyDamage); line.Outstanding != Dollars(100) {
+		t.Fatalf("unexpected line outstanding: %+v", line)
+	}
+}
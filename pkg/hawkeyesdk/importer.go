@@ -0,0 +1,514 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ImportCarrierField is the mapping target for a column holding insurance
+// company names, which the importer resolves to InsCompaniesID.
+const ImportCarrierField = "carrier"
+
+// ColumnMapping maps CSV header names to ClaimPost JSON field names, for
+// example "DOL" to "dateofloss". Headers are matched ignoring case, spaces
+// and punctuation, so a header already spelled like a JSON field ("Renter
+// Name") needs no entry. Map a header to "" to ignore the column.
+type ColumnMapping map[string]string
+
+// DefaultColumnMapping returns the header aliases the importer understands
+// out of the box. The returned map may be modified.
+func DefaultColumnMapping() ColumnMapping {
+	return ColumnMapping{
+		"renter":            "rentername",
+		"customer":          "rentername",
+		"phone":             "renterphone",
+		"email":             "renteremail",
+		"client claim":      "clientclaimno",
+		"client claim no":   "clientclaimno",
+		"carrier":           ImportCarrierField,
+		"carrier name":      ImportCarrierField,
+		"insurance company": ImportCarrierField,
+		"insurer":           ImportCarrierField,
+		"carrier id":        "inscompaniesid",
+		"claim":             "claimnumber",
+		"claim no":          "claimnumber",
+		"insured":           "insuredname",
+		"policy":            "policynumber",
+		"policy no":         "policynumber",
+		"dol":               "dateofloss",
+		"loss date":         "dateofloss",
+		"year":              "vehyear",
+		"make":              "vehmake",
+		"model":             "vehmodel",
+		"color":             "vehcolor",
+		"vin":               "vehvin",
+		"edition":           "vehedition",
+		"trim":              "vehedition",
+		"plate":             "vehplatenumber",
+		"plate number":      "vehplatenumber",
+		"license plate":     "vehplatenumber",
+		"unit":              "vehunitnumber",
+		"unit number":       "vehunitnumber",
+		"location":          "vehlocationdetails",
+		"city":              "vehlocationcity",
+		"state":             "vehlocationstate",
+		"notes":             "note",
+	}
+}
+
+func normalizeHeader(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// claimPostFields indexes the ClaimPost fields by JSON name.
+var claimPostFields = func() map[string]int {
+	fields := make(map[string]int)
+	t := reflect.TypeOf(ClaimPost{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		fields[name] = i
+	}
+	return fields
+}()
+
+// CarrierResolver turns an insurance company name from an import file into
+// the company whose ID is sent as InsCompaniesID.
+type CarrierResolver interface {
+	ResolveCarrier(ctx context.Context, name string) (InsCompany, error)
+}
+
+// CarrierResolverFunc adapts a function to a CarrierResolver.
+type CarrierResolverFunc func(ctx context.Context, name string) (InsCompany, error)
+
+func (f CarrierResolverFunc) ResolveCarrier(ctx context.Context, name string) (InsCompany, error) {
+	return f(ctx, name)
+}
+
+// NewCarrierResolver resolves names with InsCompaniesService. Only a
+// suggestion whose name matches exactly, ignoring case and punctuation, is
+// accepted, so a near miss never files claims against the wrong carrier.
+// Results are cached.
+func NewCarrierResolver(insCompanies *InsCompaniesService) CarrierResolver {
+	return &carrierLookup{insCompanies: insCompanies, cache: make(map[string]InsCompany)}
+}
+
+type carrierLookup struct {
+	insCompanies *InsCompaniesService
+
+	mu    sync.Mutex
+	cache map[string]InsCompany
+}
+
+func (l *carrierLookup) ResolveCarrier(ctx context.Context, name string) (InsCompany, error) {
+	key := normalizeHeader(name)
+	l.mu.Lock()
+	company, ok := l.cache[key]
+	l.mu.Unlock()
+	if ok {
+		return company, nil
+	}
+
+	companies, err := l.insCompanies.GetInsuranceCompanies(ctx, WithQueryParameters(name, 20))
+	if err != nil {
+		return InsCompany{}, fmt.Errorf("failed to look up carrier %q: %w", name, err)
+	}
+
+	var matches []InsCompany
+	for _, c := range companies {
+		if normalizeHeader(c.Name) == key {
+			matches = append(matches, c)
+		}
+	}
+	switch {
+	case len(matches) == 1:
+		company = matches[0]
+	case len(matches) > 1:
+		return InsCompany{}, newSentinelError(ErrValidation, "carrier %q is ambiguous: %s", name, carrierNames(matches))
+	case len(companies) == 0:
+		return InsCompany{}, newSentinelError(ErrNotFound, "no insurance company matches %q", name)
+	case len(companies) == 1:
+		return InsCompany{}, newSentinelError(ErrNotFound, "no insurance company is named %q; did you mean %s?", name, carrierNames(companies))
+	default:
+		return InsCompany{}, newSentinelError(ErrValidation, "carrier %q is ambiguous: %s", name, carrierNames(companies))
+	}
+
+	l.mu.Lock()
+	l.cache[key] = company
+	l.mu.Unlock()
+	return company, nil
+}
+
+// carrierNames lists up to five names for an error message.
+func carrierNames(companies []InsCompany) string {
+	names := make([]string, 0, 5)
+	for _, c := range companies[:min(len(companies), 5)] {
+		names = append(names, strconv.Quote(c.Name))
+	}
+	return strings.Join(names, ", ")
+}
+
+type ImportOption func(*importOptions)
+
+type importOptions struct {
+	mapping     ColumnMapping
+	comma       rune
+	concurrency int
+	dryRun      bool
+	atomic      bool
+	resolver    CarrierResolver
+}
+
+// WithColumnMapping adds header mappings on top of DefaultColumnMapping,
+// replacing defaults for the same header.
+func WithColumnMapping(mapping ColumnMapping) ImportOption {
+	return func(opts *importOptions) {
+		for header, field := range mapping {
+			opts.mapping[normalizeHeader(header)] = field
+		}
+	}
+}
+
+// WithImportComma sets the field delimiter, for example '\t' or ';' for
+// spreadsheet exports. The default is ','.
+func WithImportComma(comma rune) ImportOption {
+	return func(opts *importOptions) {
+		opts.comma = comma
+	}
+}
+
+// WithImportConcurrency sets how many claims are created at once. The
+// default is 4; the client's rate limit and concurrency cap still apply.
+func WithImportConcurrency(n int) ImportOption {
+	return func(opts *importOptions) {
+		if n > 0 {
+			opts.concurrency = n
+		}
+	}
+}
+
+// WithDryRun parses, resolves carriers and validates every row without
+// creating any claims.
+func WithDryRun(dryRun bool) ImportOption {
+	return func(opts *importOptions) {
+		opts.dryRun = dryRun
+	}
+}
+
+// WithAllOrNothing creates no claims unless every row is valid and its
+// carrier resolves. The valid rows then fail with ErrValidation so they can
+// be retried once the file is fixed.
+func WithAllOrNothing(allOrNothing bool) ImportOption {
+	return func(opts *importOptions) {
+		opts.atomic = allOrNothing
+	}
+}
+
+// WithCarrierResolver replaces the InsCompaniesService lookup, for example
+// with a fixed table of partner carrier names.
+func WithCarrierResolver(resolver CarrierResolver) ImportOption {
+	return func(opts *importOptions) {
+		opts.resolver = resolver
+	}
+}
+
+// ClaimImporter creates claims from CSV files such as partner loss runs.
+type ClaimImporter struct {
+	claims  *ClaimsService
+	options importOptions
+}
+
+// NewClaimImporter returns an importer that creates claims with claims and
+// resolves carrier names with insCompanies.
+func NewClaimImporter(claims *ClaimsService, insCompanies *InsCompaniesService, opts ...ImportOption) *ClaimImporter {
+	options := importOptions{
+		mapping:     make(ColumnMapping),
+		comma:       ',',
+		concurrency: 4,
+		resolver:    NewCarrierResolver(insCompanies),
+	}
+	WithColumnMapping(DefaultColumnMapping())(&options)
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &ClaimImporter{claims: claims, options: options}
+}
+
+// ImportResult is the outcome of one CSV row. Err is nil when the claim was
+// created or, in a dry run, would have been sent. Otherwise it is a
+// *ValidationError for bad fields, the carrier lookup error, or the error
+// from CreateClaim.
+type ImportResult struct {
+	Line    int
+	Claim   ClaimPost
+	Carrier string
+	// InsCompany is the name of the company Carrier resolved to, empty when
+	// the row gave InsCompaniesID directly.
+	InsCompany string
+	Filenumber int
+	Response   ApiResponse
+	Err        error
+}
+
+// ImportReport lists the results in file order.
+type ImportReport struct {
+	DryRun          bool
+	Results         []ImportResult
+	UnmappedColumns []string
+}
+
+// Succeeded counts the rows without an error.
+func (r *ImportReport) Succeeded() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed counts the rows with an error.
+func (r *ImportReport) Failed() int {
+	return len(r.Results) - r.Succeeded()
+}
+
+// Err joins the row errors, each prefixed with its line, or returns nil.
+func (r *ImportReport) Err() error {
+	var errs []error
+	for _, res := range r.Results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", res.Line, res.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Import reads a CSV file with a header row and creates a claim for every
+// non-blank row. Every row is parsed, resolved and validated before any
+// claim is created, but a row that fails is only skipped: the other rows are
+// still created unless WithAllOrNothing is set. Importing the same file
+// again creates those claims a second time. Row failures are recorded in the
+// report; the error is reserved for problems with the file or mapping as a
+// whole, in which case nothing was created.
+func (im *ClaimImporter) Import(ctx context.Context, r io.Reader) (*ImportReport, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = im.options.comma
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, newSentinelError(ErrValidation, "import file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import header: %w", err)
+	}
+
+	report := &ImportReport{DryRun: im.options.dryRun}
+	columns, err := im.mapColumns(header, report)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read import file: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		report.Results = append(report.Results, parseImportRow(line, record, columns))
+	}
+
+	if err := im.resolveCarriers(ctx, report.Results); err != nil {
+		return nil, err
+	}
+
+	for i := range report.Results {
+		res := &report.Results[i]
+		if res.Err != nil {
+			continue
+		}
+		if err := res.Claim.ValidateForCreate(im.claims.client.validationOptions()...); err != nil {
+			res.Err = err
+		}
+	}
+
+	if im.options.atomic && !im.options.dryRun {
+		if failed := report.Failed(); failed > 0 {
+			for i := range report.Results {
+				if report.Results[i].Err == nil {
+					report.Results[i].Err = newSentinelError(ErrValidation, "not created because %d other rows failed", failed)
+				}
+			}
+		}
+	}
+
+	if !im.options.dryRun {
+		im.create(ctx, report.Results)
+	}
+	return report, nil
+}
+
+// mapColumns returns the ClaimPost JSON field for every column, "" for
+// columns that are ignored.
+func (im *ClaimImporter) mapColumns(header []string, report *ImportReport) ([]string, error) {
+	mapping := im.options.mapping
+	for h, f := range mapping {
+		if _, ok := claimPostFields[f]; !ok && f != ImportCarrierField && f != "" {
+			return nil, newSentinelError(ErrConfig, "column %q maps to unknown field %q", h, f)
+		}
+	}
+
+	columns := make([]string, len(header))
+	seen := make(map[string]string)
+	for i, h := range header {
+		if i == 0 {
+			h = strings.TrimPrefix(h, "\ufeff")
+		}
+		key := normalizeHeader(h)
+		field, ok := mapping[key]
+		if !ok {
+			if _, isField := claimPostFields[key]; isField {
+				field, ok = key, true
+			}
+		}
+		if !ok {
+			report.UnmappedColumns = append(report.UnmappedColumns, h)
+			continue
+		}
+		if field == "" {
+			continue
+		}
+		if prev, dup := seen[field]; dup {
+			return nil, newSentinelError(ErrConfig, "columns %q and %q both map to %s", prev, h, field)
+		}
+		seen[field] = h
+		columns[i] = field
+	}
+	return columns, nil
+}
+
+func isBlankRecord(record []string) bool {
+	return !slices.ContainsFunc(record, func(s string) bool { return strings.TrimSpace(s) != "" })
+}
+
+func parseImportRow(line int, record, columns []string) ImportResult {
+	res := ImportResult{Line: line}
+	var v claimValidator
+	claim := reflect.ValueOf(&res.Claim).Elem()
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		if i >= len(columns) || columns[i] == "" || value == "" {
+			continue
+		}
+		if columns[i] == ImportCarrierField {
+			res.Carrier = value
+			continue
+		}
+		field := claim.Field(claimPostFields[columns[i]])
+		name := claim.Type().Field(claimPostFields[columns[i]]).Name
+		switch field.Kind() {
+		case reflect.Int:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				v.add(name, RuleFormat, "%s must be a whole number", name)
+				continue
+			}
+			field.SetInt(int64(n))
+		default:
+			field.SetString(value)
+		}
+	}
+
+	// Spreadsheets write dates like 3/1/2024; send them as YYYY-MM-DD.
+	if d, err := ParseDate(res.Claim.DateOfLoss); err == nil && !d.IsZero() {
+		res.Claim.DateOfLoss = d.Format(DateLayoutISO)
+	}
+	res.Err = v.err()
+	return res
+}
+
+// resolveCarriers looks up each distinct carrier name once. An explicit
+// InsCompaniesID column takes precedence over the carrier name.
+func (im *ClaimImporter) resolveCarriers(ctx context.Context, results []ImportResult) error {
+	resolved := make(map[string]InsCompany)
+	failed := make(map[string]error)
+	for i := range results {
+		res := &results[i]
+		if res.Err != nil || res.Carrier == "" || res.Claim.InsCompaniesID != "" {
+			continue
+		}
+		company, ok := resolved[res.Carrier]
+		if !ok && failed[res.Carrier] == nil {
+			var err error
+			company, err = im.options.resolver.ResolveCarrier(ctx, res.Carrier)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				failed[res.Carrier] = err
+			} else {
+				resolved[res.Carrier] = company
+			}
+		}
+		if err := failed[res.Carrier]; err != nil {
+			res.Err = err
+			continue
+		}
+		res.Claim.InsCompaniesID = strconv.Itoa(company.Id)
+		res.InsCompany = company.Name
+	}
+	return nil
+}
+
+// create sends the valid rows with a bounded number of workers.
+func (im *ClaimImporter) create(ctx context.Context, results []ImportResult) {
+	work := make(chan *ImportResult)
+	var wg sync.WaitGroup
+	for range im.options.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for res := range work {
+				res.Response, res.Err = im.claims.CreateClaim(ctx, res.Claim)
+				res.Filenumber = res.Response.Filenumber
+				if res.Err == nil && !res.Response.Success {
+					res.Err = fmt.Errorf("claim was not created: %s", res.Response.Message)
+				}
+			}
+		}()
+	}
+
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			results[i].Err = ctx.Err()
+			continue
+		}
+		work <- &results[i]
+	}
+	close(work)
+	wg.Wait()
+}
@@ -0,0 +1,219 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testImportCSV = "\ufeffRenter Name,VIN,Plate,DOL,Carrier Name,Make,Model,Color,Year,Ignored\n" +
+	"Ada Lovelace,1HGCM82633A004352,ABC123,3/1/2024,Acme Mutual,Honda,Accord,Blue,2003,x\n" +
+	",,,,,,,,,\n" +
+	"Grace Hopper,1HGCM82633A004353,XYZ9,2024-02-10,acme mutual,Ford,Focus,Red,2019,\n" +
+	"Alan Turing,11111111111111111,T1,2024-01-05,Zeta Insurance,Ford,F-150,White,twenty,\n" +
+	"Joan Clarke,1M8GDM9AXKP042788,JC1,01/20/2024,Acme Mutual,Ford,Fiesta,Green,,\n"
+
+type importServer struct {
+	mu       sync.Mutex
+	lookups  map[string]int
+	created  []ClaimPost
+	nextFile int
+}
+
+func newImportServer(t *testing.T) (*importServer, func(...ImportOption) *ClaimImporter) {
+	t.Helper()
+
+	s := &importServer{lookups: make(map[string]int), nextFile: 1000}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch r.URL.Path {
+		case "/inscompanies":
+			q := r.URL.Query().Get("q")
+			s.lookups[q]++
+			var suggestions []InsCompany
+			if strings.Contains(strings.ToLower(q), "acme") {
+				suggestions = []InsCompany{{Id: 7, Name: "Acme Mutual Holdings"}, {Id: 12, Name: "ACME Mutual"}}
+			}
+			if strings.Contains(strings.ToLower(q), "globex") {
+				suggestions = []InsCompany{{Id: 30, Name: "Globex Insurance Co."}}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"query": q, "suggestions": suggestions})
+		case "/createclaim":
+			var claim ClaimPost
+			if err := json.NewDecoder(r.Body).Decode(&claim); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			s.created = append(s.created, claim)
+			s.nextFile++
+			json.NewEncoder(w).Encode(ApiResponse{Filenumber: s.nextFile, Message: "created", Success: true})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{AuthToken: "test-token", BaseUrl: server.URL, HTTPClient: server.Client(), VINCheckDigit: true}
+	claims, insCompanies := NewClaimsService(client), NewInsCompaniesService(client)
+	newImporter := func(opts ...ImportOption) *ClaimImporter {
+		return NewClaimImporter(claims, insCompanies, opts...)
+	}
+	return s, newImporter
+}
+
+func TestClaimImporter_Import(t *testing.T) {
+	t.Parallel()
+
+	server, newImporter := newImportServer(t)
+	report, err := newImporter(WithImportConcurrency(2)).Import(context.Background(), strings.NewReader(testImportCSV))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(report.Results) != 4 || report.Succeeded() != 2 || report.Failed() != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if len(report.UnmappedColumns) != 1 || report.UnmappedColumns[0] != "Ignored" {
+		t.Fatalf("expected Ignored to be unmapped, got %v", report.UnmappedColumns)
+	}
+
+	ada := report.Results[0]
+	if ada.Line != 2 || ada.Err != nil || ada.Filenumber == 0 {
+		t.Fatalf("unexpected result for the first row: %+v", ada)
+	}
+	if ada.InsCompany != "ACME Mutual" || ada.Claim.InsCompaniesID != "12" || ada.Claim.DateOfLoss != "2024-03-01" || ada.Claim.VehYear != 2003 || ada.Claim.VehPlateNumber != "ABC123" {
+		t.Fatalf("unexpected claim: %+v", ada.Claim)
+	}
+
+	grace := report.Results[1]
+	var verr *ValidationError
+	if grace.Line != 4 || !errors.As(grace.Err, &verr) || len(verr.FieldErrors("VehVIN")) != 1 {
+		t.Fatalf("expected a VIN validation error on line 4, got %+v", grace)
+	}
+
+	alan := report.Results[2]
+	if !errors.As(alan.Err, &verr) || len(verr.FieldErrors("VehYear")) != 1 {
+		t.Fatalf("expected a VehYear format error, got %v", alan.Err)
+	}
+
+	if report.Results[3].Err != nil || report.Results[3].Filenumber == ada.Filenumber {
+		t.Fatalf("unexpected result for the last row: %+v", report.Results[3])
+	}
+	if len(server.created) != 2 {
+		t.Fatalf("expected 2 claims to be created, got %d", len(server.created))
+	}
+	// Rows sharing a carrier look it up once, and rows that already failed
+	// are not looked up at all.
+	if server.lookups["Acme Mutual"] != 1 || server.lookups["Zeta Insurance"] != 0 {
+		t.Fatalf("unexpected carrier lookups: %v", server.lookups)
+	}
+
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "line 4:") || !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected joined row errors, got %v", err)
+	}
+}
+
+func TestClaimImporter_DryRunAndCarrierErrors(t *testing.T) {
+	t.Parallel()
+
+	server, newImporter := newImportServer(t)
+	input := "renter,vin,dol,carrier,make,model,color\n" +
+		"Ada Lovelace,1HGCM82633A004352,2024-03-01,Zeta Insurance,Honda,Accord,Blue\n" +
+		"Grace Hopper,1HGCM82633A004352,2024-03-01,Acme,Honda,Accord,Blue\n" +
+		"Alan Turing,1HGCM82633A004352,2024-03-01,Globex,Honda,Accord,Blue\n" +
+		"Joan Clarke,1HGCM82633A004352,2024-03-01,GLOBEX INSURANCE CO,Honda,Accord,Blue\n"
+
+	report, err := newImporter(WithDryRun(true)).Import(context.Background(), strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !report.DryRun || len(server.created) != 0 {
+		t.Fatalf("expected a dry run to create nothing, created %d", len(server.created))
+	}
+	if !errors.Is(report.Results[0].Err, ErrNotFound) {
+		t.Fatalf("expected an unknown carrier to be ErrNotFound, got %v", report.Results[0].Err)
+	}
+	if err := report.Results[1].Err; !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected an ambiguous carrier error, got %v", err)
+	}
+	// A single suggestion is only a hint; the name has to match it.
+	if err := report.Results[2].Err; !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), `"Globex Insurance Co."`) {
+		t.Fatalf("expected a near miss to be ErrNotFound with a suggestion, got %v", err)
+	}
+	if res := report.Results[3]; res.Err != nil || res.InsCompany != "Globex Insurance Co." || res.Claim.InsCompaniesID != "30" {
+		t.Fatalf("expected a match ignoring case and punctuation, got %+v", res)
+	}
+}
+
+func TestClaimImporter_AllOrNothing(t *testing.T) {
+	t.Parallel()
+
+	server, newImporter := newImportServer(t)
+	report, err := newImporter(WithAllOrNothing(true)).Import(context.Background(), strings.NewReader(testImportCSV))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(server.created) != 0 || report.Failed() != 4 {
+		t.Fatalf("expected nothing to be created, created %d: %+v", len(server.created), report.Results)
+	}
+	if err := report.Results[0].Err; !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), "2 other rows failed") {
+		t.Fatalf("expected the valid row to report why it was not created, got %v", err)
+	}
+
+	valid := "renter,vin,dol,carrier,make,model,color\n" +
+		"Ada Lovelace,1HGCM82633A004352,2024-03-01,Acme Mutual,Honda,Accord,Blue\n"
+	report, err = newImporter(WithAllOrNothing(true)).Import(context.Background(), strings.NewReader(valid))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Failed() != 0 || len(server.created) != 1 {
+		t.Fatalf("expected a valid file to be created, got %+v", report.Results)
+	}
+}
+
+func TestClaimImporter_Mapping(t *testing.T) {
+	t.Parallel()
+
+	_, newImporter := newImportServer(t)
+	resolver := CarrierResolverFunc(func(ctx context.Context, name string) (InsCompany, error) {
+		return InsCompany{Id: 99, Name: "Acme Mutual"}, nil
+	})
+
+	input := "Customer Nm;Serial;Loss Dt;Ins Co;Make;Model;Color;VIN\n" +
+		"Ada Lovelace;1HGCM82633A004352;2024-03-01;Acme;Honda;Accord;Blue;ignored\n"
+	report, err := newImporter(
+		WithDryRun(true),
+		WithImportComma(';'),
+		WithCarrierResolver(resolver),
+		WithColumnMapping(ColumnMapping{"Customer Nm": "rentername", "serial": "vehvin", "LOSS DT": "dateofloss", "Ins Co": ImportCarrierField, "VIN": ""}),
+	).Import(context.Background(), strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	res := report.Results[0]
+	if res.Err != nil || res.Claim.RenterName != "Ada Lovelace" || res.Claim.VehVIN != "1HGCM82633A004352" || res.Claim.InsCompaniesID != "99" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mapping ColumnMapping
+		input   string
+	}{
+		{name: "unknown field", mapping: ColumnMapping{"x": "colour"}, input: "x\n1\n"},
+		{name: "duplicate field", input: "VIN,Veh VIN\n1,2\n"},
+	} {
+		if _, err := newImporter(WithColumnMapping(tt.mapping)).Import(context.Background(), strings.NewReader(tt.input)); !errors.Is(err, ErrConfig) {
+			t.Fatalf("%s: expected ErrConfig, got %v", tt.name, err)
+		}
+	}
+
+	if _, err := newImporter().Import(context.Background(), strings.NewReader("vin\n\"unterminated\n")); err == nil {
+		t.Fatal("expected a malformed file to fail")
+	}
+}
@@ -3,8 +3,8 @@ package hawkeyesdk
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -62,25 +62,14 @@ func (s *InsCompaniesService) GetInsuranceCompanies(ctx context.Context, opts ..
 
 	var insCompanies []InsCompany
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return insCompanies, fmt.Errorf("failed to create request: %w", err)
+	request := apiRequest{
+		operation: "GetInsuranceCompanies",
+		method:    http.MethodGet,
+		url:       u.String(),
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.client.AuthToken))
-
-	resp, err := s.client.HTTPClient.Do(req)
+	bodyBytes, err := s.client.do(ctx, request)
 	if err != nil {
-		return insCompanies, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return insCompanies, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if err := checkResponse(resp); err != nil {
 		return insCompanies, err
 	}
 
@@ -96,23 +85,23 @@ func (s *InsCompaniesService) GetInsuranceCompanies(ctx context.Context, opts ..
 	var genericResp map[string]any
 
 	if err := json.Unmarshal(bodyBytes, &genericResp); err != nil {
-		return insCompanies, fmt.Errorf("failed to decode response: %w", err)
+		return insCompanies, request.decodeError(bodyBytes, err)
 	}
 
 	if _, ok := genericResp["data"]; ok {
 		var fr fullResponse
 		if err := json.Unmarshal(bodyBytes, &fr); err != nil {
-			return insCompanies, fmt.Errorf("failed to decode full response: %w", err)
+			return insCompanies, request.decodeError(bodyBytes, fmt.Errorf("full response: %w", err))
 		}
 		insCompanies = fr.Data
 	} else if _, ok := genericResp["suggestions"]; ok {
 		var qr queryResponse
 		if err := json.Unmarshal(bodyBytes, &qr); err != nil {
-			return insCompanies, fmt.Errorf("failed to decode query response: %w", err)
+			return insCompanies, request.decodeError(bodyBytes, fmt.Errorf("query response: %w", err))
 		}
 		insCompanies = qr.Suggestions
 	} else {
-		return insCompanies, fmt.Errorf("unexpected response format")
+		return insCompanies, request.decodeError(bodyBytes, errors.New("unexpected response format"))
 	}
 
 	return insCompanies, nil
@@ -0,0 +1,259 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Iterator streams the elements of a JSON array response one at a time, so
+// memory use does not grow with the size of the result.
+//
+// Paging is opt-in: only when a page size is set does it request the
+// following pages, until a page comes back short or brings no rows that were
+// not on the page before it. Rows repeated from the previous page are
+// skipped, so a server that ignores the paging parameters cannot make the
+// iterator loop forever.
+//
+// The concurrency slot of WithMaxConcurrency is held only while a page is
+// requested, not while it is read, so the loop body may call the API.
+//
+//	it := client.Claims.IterateClaims(ctx)
+//	defer it.Close()
+//	for it.Next() {
+//		claim := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	ctx      context.Context
+	client   *ClientSettings
+	request  func(page int) apiRequest
+	pageSize int
+
+	page    int
+	current apiRequest
+	body    io.ReadCloser
+	dec     *json.Decoder
+	inPage  int
+	value   T
+	err     error
+	done    bool
+
+	// Hashes of the rows on the previous and current page, used to detect a
+	// server that ignores the paging parameters.
+	seed     maphash.Seed
+	previous map[uint64]struct{}
+	rows     map[uint64]struct{}
+	newRows  int
+}
+
+func newIterator[T any](ctx context.Context, client *ClientSettings, pageSize int, request func(page int) apiRequest) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, client: client, request: request, pageSize: pageSize, page: 1, seed: maphash.MakeSeed()}
+}
+
+// Next advances to the next element. It returns false at the end of the
+// results or on error; check Err afterwards.
+func (it *Iterator[T]) Next() bool {
+	for {
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.fail(err)
+			return false
+		}
+
+		if it.dec == nil {
+			if !it.open() {
+				continue
+			}
+		}
+
+		if it.dec.More() {
+			var raw json.RawMessage
+			if err := it.dec.Decode(&raw); err != nil {
+				it.fail(it.streamError(err))
+				return false
+			}
+			it.inPage++
+			if !it.remember(raw) {
+				continue
+			}
+			var value T
+			if err := it.client.decodeJSON(raw, &value); err != nil {
+				it.fail(it.current.decodeError(raw, err))
+				return false
+			}
+			it.value = value
+			return true
+		}
+
+		if _, err := it.dec.Token(); err != nil {
+			it.fail(it.streamError(err))
+			return false
+		}
+		it.closeBody()
+
+		if it.pageSize > 0 && it.inPage == it.pageSize && it.newRows > 0 {
+			it.page++
+			it.inPage = 0
+			it.previous, it.rows, it.newRows = it.rows, nil, 0
+			continue
+		}
+		it.done = true
+	}
+}
+
+// open requests the current page and consumes the opening bracket. It
+// returns false when the page is empty or failed.
+func (it *Iterator[T]) open() bool {
+	it.current = it.request(it.page)
+	it.current.stream = true
+	resp, err := it.client.send(it.ctx, it.current)
+	if err != nil {
+		it.fail(err)
+		return false
+	}
+	it.body = resp.Body
+	it.dec = json.NewDecoder(resp.Body)
+
+	tok, err := it.dec.Token()
+	if err == io.EOF || (err == nil && tok == nil) {
+		// An empty body or null is an empty result.
+		it.closeBody()
+		it.done = true
+		return false
+	}
+	if err != nil {
+		it.fail(it.streamError(err))
+		return false
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		it.fail(it.streamError(fmt.Errorf("expected a JSON array, got %v", tok)))
+		return false
+	}
+	return true
+}
+
+// remember records a row of the current page and reports whether it is new,
+// that is, not also on the previous page. Without paging every row is new.
+func (it *Iterator[T]) remember(raw []byte) bool {
+	if it.pageSize <= 0 {
+		it.newRows++
+		return true
+	}
+	if it.rows == nil {
+		it.rows = make(map[uint64]struct{}, it.pageSize)
+	}
+	hash := maphash.Bytes(it.seed, raw)
+	it.rows[hash] = struct{}{}
+	if _, ok := it.previous[hash]; ok {
+		return false
+	}
+	it.newRows++
+	return true
+}
+
+func (it *Iterator[T]) streamError(err error) error {
+	if ctxErr := it.ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return it.current.decodeError(nil, err)
+}
+
+func (it *Iterator[T]) fail(err error) {
+	it.err = err
+	it.closeBody()
+}
+
+func (it *Iterator[T]) closeBody() {
+	if it.body != nil {
+		it.body.Close()
+		it.body = nil
+	}
+	it.dec = nil
+}
+
+// Value returns the current element.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close releases the underlying response. It is safe to call more than once
+// and after the iteration has finished.
+func (it *Iterator[T]) Close() error {
+	it.closeBody()
+	it.done = true
+	return nil
+}
+
+// collect drains it into a slice.
+func collect[T any](it *Iterator[T]) ([]T, error) {
+	defer it.Close()
+
+	var values []T
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// withPage adds pagination parameters to rawURL when pageSize is set.
+func withPage(rawURL string, pageSize, page int) string {
+	if pageSize <= 0 {
+		return rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("pagesize", strconv.Itoa(pageSize))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// IterateClaims streams the claims returned by GetClaims.
+func (s *ClaimsService) IterateClaims(ctx context.Context, opts ...GetClaimsOption) *Iterator[Claim] {
+	options := getClaimsOptions{includeInactive: false}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return newIterator[Claim](ctx, s.client, options.pageSize, func(page int) apiRequest {
+		return apiRequest{
+			operation: "GetClaims",
+			method:    http.MethodGet,
+			url:       withPage(s.client.BaseUrl+fmt.Sprintf("/getclaims/all/%t", options.includeInactive), options.pageSize, page),
+		}
+	})
+}
+
+// IterateAdminClaims streams the claims returned by GetAdminClaims.
+func (s *ClaimsService) IterateAdminClaims(ctx context.Context, opts ...GetAdminClaimsOption) *Iterator[AdminClaim] {
+	options := getAdminClaimsOptions{includeInactive: false, docfiles: false, logtrail: false}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	base := s.adminClaimsRequest(options)
+	return newIterator[AdminClaim](ctx, s.client, options.pageSize, func(page int) apiRequest {
+		request := base
+		request.url = withPage(base.url, options.pageSize, page)
+		return request
+	})
+}
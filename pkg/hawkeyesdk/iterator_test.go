@@ -0,0 +1,268 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClaimsService_IterateClaims_Streams(t *testing.T) {
+	t.Parallel()
+
+	const total = 5000
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/getclaims/all/true" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no pagination parameters, got %q", r.URL.RawQuery)
+		}
+		w.Write([]byte("["))
+		for i := 1; i <= total; i++ {
+			if i > 1 {
+				w.Write([]byte(","))
+			}
+			fmt.Fprintf(w, `{"filenumber":%d,"rentername":"Renter %d"}`, i, i)
+		}
+		w.Write([]byte("]"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	it := NewClaimsService(client).IterateClaims(context.Background(), WithIncludeInactive(true))
+	defer it.Close()
+
+	count := 0
+	for it.Next() {
+		count++
+		if it.Value().Filenumber != count {
+			t.Fatalf("expected filenumber %d, got %d", count, it.Value().Filenumber)
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if count != total {
+		t.Fatalf("expected %d claims, got %d", total, count)
+	}
+	if it.Next() {
+		t.Fatalf("expected exhausted iterator to stay exhausted")
+	}
+}
+
+func TestClaimsService_IterateAdminClaims_Pages(t *testing.T) {
+	t.Parallel()
+
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("docfiles") != "true" || query.Get("pagesize") != "2" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		page, _ := strconv.Atoi(query.Get("page"))
+		pages = append(pages, query.Get("page"))
+		switch page {
+		case 1:
+			w.Write([]byte(`[{"filenumber":1},{"filenumber":2}]`))
+		case 2:
+			w.Write([]byte(`[{"filenumber":3},{"filenumber":4}]`))
+		default:
+			w.Write([]byte(`[{"filenumber":5}]`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	claims, err := NewClaimsService(client).GetAdminClaims(context.Background(), WithDocFiles(true), WithAdminPageSize(2))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(claims) != 5 || claims[4].Filenumber != 5 {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+	if fmt.Sprint(pages) != "[1 2 3]" {
+		t.Fatalf("unexpected pages requested: %v", pages)
+	}
+}
+
+func TestClaimsService_IterateClaims_IgnoredPagination(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`[{"filenumber":1},{"filenumber":2},{"filenumber":3}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	claims, err := NewClaimsService(client).GetClaims(context.Background(), WithPageSize(2))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(claims) != 3 || requests != 1 {
+		t.Fatalf("expected a server without pagination to be read once, got %d claims in %d requests", len(claims), requests)
+	}
+}
+
+func TestClaimsService_IterateClaims_RepeatedPages(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		switch {
+		case r.URL.Path == "/getclaims/all/false":
+			// Ignores the paging parameters and returns a full page.
+			w.Write([]byte(`[{"filenumber":1},{"filenumber":2}]`))
+		case page == "1":
+			w.Write([]byte(`[{"filenumber":1},{"filenumber":2}]`))
+		default:
+			// Overlaps the previous page by one row, then repeats itself.
+			w.Write([]byte(`[{"filenumber":2},{"filenumber":3}]`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	service := NewClaimsService(client)
+
+	claims, err := service.GetClaims(context.Background(), WithPageSize(2))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(claims) != 2 || fmt.Sprint(pages) != "[1 2]" {
+		t.Fatalf("expected a repeated page to end iteration, got %d claims from pages %v", len(claims), pages)
+	}
+
+	pages = nil
+	claims, err = service.GetClaims(context.Background(), WithIncludeInactive(true), WithPageSize(2))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var got []int
+	for _, claim := range claims {
+		got = append(got, claim.Filenumber)
+	}
+	if fmt.Sprint(got) != "[1 2 3]" || fmt.Sprint(pages) != "[1 2 3]" {
+		t.Fatalf("expected overlapping rows to be skipped, got claims %v from pages %v", got, pages)
+	}
+}
+
+func TestClaimsService_IterateClaims_Errors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getclaims/all/false":
+			w.Write([]byte(`[{"filenumber":1},{"filenumber":"two"}]`))
+		case "/getclaims/all/true":
+			w.Write([]byte(`null`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	service := NewClaimsService(client)
+
+	it := service.IterateClaims(context.Background())
+	if !it.Next() || it.Value().Filenumber != 1 {
+		t.Fatalf("expected first claim before the bad element")
+	}
+	if it.Next() {
+		t.Fatalf("expected bad element to stop iteration")
+	}
+	if !errors.Is(it.Err(), ErrDecode) {
+		t.Fatalf("expected ErrDecode, got %v", it.Err())
+	}
+
+	empty := service.IterateClaims(context.Background(), WithIncludeInactive(true))
+	if empty.Next() || empty.Err() != nil {
+		t.Fatalf("expected null response to be an empty result, got %v", empty.Err())
+	}
+}
+
+func TestClaimsService_IterateClaims_CloseReleasesSlot(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"filenumber":1},{"filenumber":2}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewHawkeyeClient("token", WithMaxConcurrency(1))
+	client.BaseUrl = server.URL
+	client.HTTPClient = server.Client()
+
+	it := client.Claims.IterateClaims(context.Background())
+	if !it.Next() {
+		t.Fatalf("expected a claim, got %v", it.Err())
+	}
+	it.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := client.Claims.GetClaims(ctx); err != nil {
+		t.Fatalf("expected closed iterator to release its concurrency slot, got %v", err)
+	}
+}
+
+func TestClaimsService_IterateClaims_NestedCalls(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"filenumber":1},{"filenumber":2}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewHawkeyeClient("token", WithMaxConcurrency(1))
+	client.BaseUrl = server.URL
+	client.HTTPClient = server.Client()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	it := client.Claims.IterateClaims(ctx)
+	defer it.Close()
+	for it.Next() {
+		if _, err := client.Claims.GetClaims(ctx); err != nil {
+			t.Fatalf("expected a call inside the loop to get the concurrency slot, got %v", err)
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
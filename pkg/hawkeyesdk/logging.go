@@ -0,0 +1,139 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// defaultRedactedHeaders are always redacted from logged request headers.
+var defaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Api-Key"}
+
+// WithLogger logs every HTTP attempt made by the services. Successful
+// attempts are logged at Info, retried ones at Warn and failures at Error.
+// Request headers and payloads are added at Debug with the bearer token,
+// upload links and claimant PII redacted.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *ClientSettings) {
+		c.Logger = logger
+	}
+}
+
+// WithRedactedHeaders redacts more request headers from logs, for example
+// secrets added by a middleware. Authorization, Proxy-Authorization, Cookie
+// and X-Api-Key are always redacted.
+func WithRedactedHeaders(names ...string) Option {
+	return func(c *ClientSettings) {
+		c.RedactedHeaders = append(c.RedactedHeaders, names...)
+	}
+}
+
+type attemptResult struct {
+	attempt   int
+	resp      *http.Response
+	err       error
+	elapsed   time.Duration
+	willRetry bool
+}
+
+func (c *ClientSettings) logAttempt(ctx context.Context, r apiRequest, req *http.Request, res attemptResult) {
+	if c.Logger == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	switch {
+	case res.willRetry:
+		level = slog.LevelWarn
+	case res.err != nil || res.resp.StatusCode >= 300:
+		level = slog.LevelError
+	}
+
+	if !c.Logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", r.operation),
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", res.attempt),
+		slog.Duration("duration", res.elapsed),
+	}
+	if r.filenumber > 0 {
+		attrs = append(attrs, slog.Int("filenumber", r.filenumber))
+	}
+	if res.resp != nil {
+		attrs = append(attrs, slog.Int("status", res.resp.StatusCode))
+	}
+	if res.err != nil {
+		attrs = append(attrs, slog.String("error", res.err.Error()))
+	}
+	if c.Logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("headers", redactHeaders(req.Header, c.RedactedHeaders)))
+		if r.payload != nil {
+			attrs = append(attrs, slog.Any("payload", r.payload))
+		}
+	}
+
+	c.Logger.LogAttrs(ctx, level, "hawkeye request", attrs...)
+}
+
+func redactHeaders(h http.Header, extra []string) map[string]string {
+	out := make(map[string]string, len(h))
+	for key, values := range h {
+		if isRedactedHeader(key, defaultRedactedHeaders) || isRedactedHeader(key, extra) {
+			out[key] = redacted
+			continue
+		}
+		out[key] = strings.Join(values, ", ")
+	}
+	return out
+}
+
+func isRedactedHeader(key string, names []string) bool {
+	for _, name := range names {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL keeps only the scheme and host of a link.
+func redactURL(link string) string {
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return redactString(link)
+	}
+	return u.Scheme + "://" + u.Host + "/" + redacted
+}
+
+func redactString(s string) string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// LogValue keeps renter and insured details out of logs.
+func (c ClaimPost) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("filenumber", c.FileNumber),
+		slog.String("clientclaimno", c.ClientClaimNo),
+		slog.String("rentername", redactString(c.RenterName)),
+		slog.String("renterphone", redactString(c.RenterPhone)),
+		slog.String("renteremail", redactString(c.RenterEmail)),
+		slog.String("inscompaniesid", c.InsCompaniesID),
+		slog.String("claimnumber", c.ClaimNumber),
+		slog.String("insuredname", redactString(c.InsuredName)),
+		slog.String("policynumber", redactString(c.PolicyNumber)),
+		slog.String("dateofloss", c.DateOfLoss),
+		slog.String("vehvin", c.VehVIN),
+	)
+}
@@ -0,0 +1,134 @@
+package hawkeyesdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientSettings_Logger_RedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(ApiResponse{Filenumber: 77, Message: "ok", Success: true})
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	client := &ClientSettings{
+		AuthToken:  "super-secret-token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))(client)
+
+	_, err := NewClaimsService(client).CreateClaim(context.Background(), ClaimPost{
+		RenterName:     "Jane Renter",
+		RenterEmail:    "jane@example.com",
+		InsuredName:    "John Insured",
+		InsCompaniesID: "Hawkeye",
+		DateOfLoss:     "2024-01-01",
+		VehMake:        "Ford",
+		VehModel:       "F150",
+		VehColor:       "Blue",
+		VehVIN:         "1HGCM82633A004352",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	out := buf.String()
+	for _, secret := range []string{"super-secret-token", "Jane Renter", "jane@example.com", "John Insured"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("log output leaked %q: %s", secret, out)
+		}
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode log record: %v", err)
+	}
+	if record["operation"] != "CreateClaim" || record["path"] != "/createclaim" || record["status"] != float64(200) || record["attempt"] != float64(1) {
+		t.Fatalf("unexpected log record: %v", record)
+	}
+	if record["level"] != "INFO" {
+		t.Fatalf("unexpected level: %v", record["level"])
+	}
+}
+
+func TestClientSettings_Logger_FailureIncludesFilenumber(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(ApiResponse{Message: "missing"})
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	WithLogger(slog.New(slog.NewJSONHandler(&buf, nil)))(client)
+
+	if _, err := NewClaimsService(client).GetSingleClaim(context.Background(), 314); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode log record: %v", err)
+	}
+	if record["level"] != "ERROR" || record["filenumber"] != float64(314) || record["status"] != float64(404) {
+		t.Fatalf("unexpected log record: %v", record)
+	}
+	if _, ok := record["headers"]; ok {
+		t.Fatalf("headers should only be logged at debug level")
+	}
+}
+
+func TestClientSettings_Logger_RedactsCustomHeadersAndLinks(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))(client)
+	WithRedactedHeaders("X-Partner-Secret")(client)
+	WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+		return func(op Operation, req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Partner-Secret", "partner-secret-value")
+			return next(op, req)
+		}
+	})(client)
+
+	link := "https://files.example.com/report.pdf?X-Amz-Signature=signed-secret"
+	if _, err := NewDocFilesService(client).UploadFileContext(context.Background(), 5, link); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	out := buf.String()
+	for _, secret := range []string{"partner-secret-value", "signed-secret", "report.pdf"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("log output leaked %q: %s", secret, out)
+		}
+	}
+	if !strings.Contains(out, `"X-Partner-Secret":"[REDACTED]"`) || !strings.Contains(out, "https://files.example.com/[REDACTED]") {
+		t.Fatalf("expected the link host to be logged, got %s", out)
+	}
+}
@@ -1,12 +1,13 @@
 package hawkeyesdk
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -22,6 +23,15 @@ func WithDate(date string) LogTrailOption {
 	}
 }
 
+// WithTime dates the entry with the calendar day of t in t's location. Use
+// t.In(loc) to choose the day as seen in another time zone, for example the
+// claim office's, rather than the machine's local zone.
+func WithTime(t time.Time) LogTrailOption {
+	return func(opts *logTrailOptions) {
+		opts.date = t.Format(DateLayoutUS)
+	}
+}
+
 type LogTrailsService struct {
 	client *ClientSettings
 }
@@ -33,7 +43,7 @@ func NewLogTrailsService(client *ClientSettings) *LogTrailsService {
 
 func (s *LogTrailsService) CreateLogTrail(ctx context.Context, filenumber int, activity string, opts ...LogTrailOption) (ApiResponse, error) {
 	options := logTrailOptions{
-		date: time.Now().Format("01/02/2006"),
+		date: time.Now().Format(DateLayoutUS),
 	}
 	for _, opt := range opts {
 		opt(&options)
@@ -56,33 +66,122 @@ func (s *LogTrailsService) CreateLogTrail(ctx context.Context, filenumber int, a
 		return ApiResponse{}, fmt.Errorf("failed to marshal post data: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.client.BaseUrl+"/createLogTrailEntry", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return ApiResponse{}, fmt.Errorf("failed to create request: %w", err)
+	request := apiRequest{
+		operation:   "CreateLogTrail",
+		method:      http.MethodPost,
+		url:         s.client.BaseUrl + "/createLogTrailEntry",
+		body:        jsonData,
+		contentType: "application/json",
+		filenumber:  filenumber,
+		payload:     payload,
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.client.AuthToken))
+	var apiResp ApiResponse
+	if err := s.client.doJSON(ctx, request, &apiResp); err != nil {
+		return ApiResponse{}, err
+	}
 
-	resp, err := s.client.HTTPClient.Do(req)
-	if err != nil {
-		return ApiResponse{}, fmt.Errorf("request failed: %w", err)
+	return apiResp, nil
+}
+
+// LogTrailEntry is a log trail entry with its date parsed. Dates without a
+// zone are interpreted as UTC. RawDate holds the date as the API sent it.
+type LogTrailEntry struct {
+	Time     time.Time `json:"time"`
+	Activity string    `json:"activity"`
+	User     string    `json:"user"`
+	RawDate  string    `json:"rawdate,omitempty"`
+}
+
+// Entry parses the date of a raw log trail, such as one embedded in an
+// AdminClaim. When the date cannot be parsed it returns the entry with a zero
+// Time along with the error.
+func (l LogTrail) Entry() (LogTrailEntry, error) {
+	entry := LogTrailEntry{Time: l.Date.Time, Activity: l.Activity, User: l.User, RawDate: l.Date.String()}
+	if l.Date.Time.IsZero() {
+		return entry, fmt.Errorf("unrecognized date format %q", l.Date.Raw())
 	}
-	defer resp.Body.Close()
+	return entry, nil
+}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return ApiResponse{}, fmt.Errorf("failed to read response body: %w", err)
+type ListLogTrailsOption func(*listLogTrailsOptions)
+
+type listLogTrailsOptions struct {
+	from time.Time
+	to   time.Time
+}
+
+// WithLogTrailDateRange keeps entries dated on the days from from through
+// to, inclusive; only the calendar date of each bound is used, matching the
+// API. A zero bound is open.
+func WithLogTrailDateRange(from, to time.Time) ListLogTrailsOption {
+	return func(opts *listLogTrailsOptions) {
+		opts.from = from
+		opts.to = to
 	}
+}
 
-	if err := checkResponse(resp); err != nil {
-		return ApiResponse{}, err
+// ListLogTrails returns the log trail entries of a claim from the endpoint
+// set with WithLogTrailEndpoint, oldest first. The date range is sent to the
+// API and applied again locally. Entries whose date cannot be parsed are
+// kept, with a zero Time, after the dated ones.
+func (s *LogTrailsService) ListLogTrails(ctx context.Context, filenumber int, opts ...ListLogTrailsOption) ([]LogTrailEntry, error) {
+	if s.client.LogTrailEndpoint == "" {
+		return nil, newSentinelError(ErrConfig, "no log trail endpoint is configured, see WithLogTrailEndpoint")
 	}
 
-	var apiResp ApiResponse
-	if err := json.Unmarshal(bodyBytes, &apiResp); err != nil {
-		return ApiResponse{}, fmt.Errorf("failed to decode response: %w", err)
+	var options listLogTrailsOptions
+	for _, opt := range opts {
+		opt(&options)
 	}
 
-	return apiResp, nil
+	endpoint := strings.Trim(s.client.LogTrailEndpoint, "/")
+	u, _ := url.Parse(s.client.BaseUrl + fmt.Sprintf("/%s/%d", endpoint, filenumber))
+	queryParams := url.Values{}
+	if !options.from.IsZero() {
+		queryParams.Add("from", options.from.Format("2006-01-02"))
+	}
+	if !options.to.IsZero() {
+		queryParams.Add("to", options.to.Format("2006-01-02"))
+	}
+	u.RawQuery = queryParams.Encode()
+
+	request := apiRequest{
+		operation:  "ListLogTrails",
+		method:     http.MethodGet,
+		url:        u.String(),
+		filenumber: filenumber,
+	}
+
+	var trails []LogTrail
+	if err := s.client.doJSON(ctx, request, &trails); err != nil {
+		return nil, err
+	}
+
+	entries := make([]LogTrailEntry, 0, len(trails))
+	for _, trail := range trails {
+		entry, err := trail.Entry()
+		if err == nil && !inDayRange(entry.Time, options.from, options.to) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	slices.SortStableFunc(entries, func(a, b LogTrailEntry) int {
+		return compareUndatedLast(a.Time, b.Time)
+	})
+
+	return entries, nil
+}
+
+// compareUndatedLast orders times chronologically with zero times last.
+func compareUndatedLast(a, b time.Time) int {
+	switch {
+	case a.IsZero() == b.IsZero():
+		return a.Compare(b)
+	case a.IsZero():
+		return 1
+	default:
+		return -1
+	}
 }
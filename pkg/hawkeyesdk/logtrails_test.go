@@ -3,9 +3,11 @@ package hawkeyesdk
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestLogTrailsService_CreateLogTrail_Success(t *testing.T) {
@@ -71,3 +73,143 @@ func TestLogTrailsService_CreateLogTrail_HTTPError(t *testing.T) {
 		t.Fatalf("expected error from server")
 	}
 }
+
+func TestLogTrailsService_ListLogTrails(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/getlogtrail/7" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("from"); got != "2024-03-01" {
+			t.Errorf("unexpected from parameter: %q", got)
+		}
+		_ = json.NewEncoder(w).Encode([]LogTrail{
+			{Date: mustParseDate(t, "03/05/2024 2:30:00 PM"), Activity: "called adjuster", User: "amy"},
+			{Date: mustParseDate(t, "2024-02-20"), Activity: "claim opened", User: "sys"},
+			{Date: mustParseDate(t, "2024-03-02T09:00:00"), Activity: "photos received", User: "bob"},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:        "token",
+		BaseUrl:          server.URL,
+		HTTPClient:       server.Client(),
+		LogTrailEndpoint: "/getlogtrail",
+	}
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	entries, err := NewLogTrailsService(client).ListLogTrails(context.Background(), 7, WithLogTrailDateRange(from, time.Time{}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected the out of range entry to be dropped, got %+v", entries)
+	}
+	if entries[0].Activity != "photos received" || entries[1].Activity != "called adjuster" {
+		t.Fatalf("expected entries in chronological order, got %+v", entries)
+	}
+	if want := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC); !entries[1].Time.Equal(want) {
+		t.Fatalf("expected parsed time %v, got %v", want, entries[1].Time)
+	}
+}
+
+func TestLogTrailsService_ListLogTrails_DaysAndBadDates(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("to"); got != "2024-03-05" {
+			t.Errorf("unexpected to parameter: %q", got)
+		}
+		_ = json.NewEncoder(w).Encode([]LogTrail{
+			{Date: Date{raw: "someday"}, Activity: "undated"},
+			{Date: mustParseDate(t, "03/05/2024 2:30:00 PM"), Activity: "afternoon"},
+			{Date: mustParseDate(t, "2024-03-06"), Activity: "next day"},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:        "token",
+		BaseUrl:          server.URL,
+		HTTPClient:       server.Client(),
+		LogTrailEndpoint: "/getlogtrail",
+	}
+
+	// The upper bound covers the whole of March 5th.
+	to := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	entries, err := NewLogTrailsService(client).ListLogTrails(context.Background(), 7, WithLogTrailDateRange(time.Time{}, to))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(entries) != 2 || entries[0].Activity != "afternoon" || entries[1].Activity != "undated" {
+		t.Fatalf("expected the afternoon entry then the undated one, got %+v", entries)
+	}
+	if !entries[1].Time.IsZero() || entries[1].RawDate != "someday" {
+		t.Fatalf("expected the undated entry to keep its raw date, got %+v", entries[1])
+	}
+}
+
+func TestLogTrailsService_ListLogTrails_DecodeError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"not": "a list"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:        "token",
+		BaseUrl:          server.URL,
+		HTTPClient:       server.Client(),
+		LogTrailEndpoint: "/getlogtrail",
+	}
+
+	_, err := NewLogTrailsService(client).ListLogTrails(context.Background(), 7)
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || !errors.Is(err, ErrDecode) {
+		t.Fatalf("expected a DecodeError, got %v", err)
+	}
+}
+
+func TestLogTrailsService_ListLogTrails_NoEndpoint(t *testing.T) {
+	t.Parallel()
+
+	client := &ClientSettings{AuthToken: "token", BaseUrl: "http://127.0.0.1:0"}
+	if _, err := NewLogTrailsService(client).ListLogTrails(context.Background(), 7); !errors.Is(err, ErrConfig) {
+		t.Fatalf("expected ErrConfig without a log trail endpoint, got %v", err)
+	}
+}
+
+func TestLogTrailsService_CreateLogTrail_WithTime(t *testing.T) {
+	t.Parallel()
+
+	dates := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Date string `json:"date"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		dates <- payload.Date
+		_ = json.NewEncoder(w).Encode(ApiResponse{Success: true})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	// 03:00 UTC on March 1st is still February 29th in New York.
+	newYork := time.FixedZone("EST", -5*60*60)
+	instant := time.Date(2024, 3, 1, 3, 0, 0, 0, time.UTC)
+
+	if _, err := NewLogTrailsService(client).CreateLogTrail(context.Background(), 7, "note", WithTime(instant.In(newYork))); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := <-dates; got != "02/29/2024" {
+		t.Fatalf("expected date in the given zone, got %q", got)
+	}
+}
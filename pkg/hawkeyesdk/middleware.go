@@ -0,0 +1,74 @@
+package hawkeyesdk
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// Operation identifies the SDK call a request belongs to.
+type Operation struct {
+	Name    string
+	Attempt int
+}
+
+// RoundTripFunc sends a single HTTP attempt for an operation.
+type RoundTripFunc func(op Operation, req *http.Request) (*http.Response, error)
+
+// Middleware wraps every HTTP attempt made by the services. Middlewares run
+// in the order they were registered, the first one being the outermost.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *ClientSettings) {
+		c.Middlewares = append(c.Middlewares, middlewares...)
+	}
+}
+
+func (c *ClientSettings) roundTripper() RoundTripFunc {
+	rt := RoundTripFunc(func(_ Operation, req *http.Request) (*http.Response, error) {
+		return c.HTTPClient.Do(req)
+	})
+	for i := len(c.Middlewares) - 1; i >= 0; i-- {
+		rt = c.Middlewares[i](rt)
+	}
+	return rt
+}
+
+// LoggingMiddleware writes one line per attempt with the operation, method,
+// URL, status and duration. A nil logger uses log.Default.
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(op Operation, req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(op, req)
+			elapsed := time.Since(start)
+			if err != nil {
+				logger.Printf("hawkeye %s attempt %d: %s %s failed after %s: %v", op.Name, op.Attempt, req.Method, req.URL.Redacted(), elapsed, err)
+				return resp, err
+			}
+			logger.Printf("hawkeye %s attempt %d: %s %s -> %d in %s", op.Name, op.Attempt, req.Method, req.URL.Redacted(), resp.StatusCode, elapsed)
+			return resp, nil
+		}
+	}
+}
+
+// HeaderMiddleware sets the given headers on every outgoing request,
+// replacing values the SDK would otherwise send.
+func HeaderMiddleware(headers http.Header) Middleware {
+	headers = headers.Clone()
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(op Operation, req *http.Request) (*http.Response, error) {
+			for key, values := range headers {
+				req.Header.Del(key)
+				for _, value := range values {
+					req.Header.Add(key, value)
+				}
+			}
+			return next(op, req)
+		}
+	}
+}
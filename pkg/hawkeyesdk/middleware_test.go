@@ -0,0 +1,109 @@
+package hawkeyesdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClientSettings_Middleware_OrderAndOperation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(ApiResponse{Message: "logged", Success: true})
+	}))
+	t.Cleanup(server.Close)
+
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(op Operation, req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+":"+op.Name)
+				resp, err := next(op, req)
+				if err == nil {
+					calls = append(calls, name+":"+resp.Status)
+				}
+				return resp, err
+			}
+		}
+	}
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	WithMiddleware(record("outer"), record("inner"))(client)
+
+	if _, err := NewLogTrailsService(client).CreateLogTrail(context.Background(), 1, "note"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"outer:CreateLogTrail",
+		"inner:CreateLogTrail",
+		"inner:200 OK",
+		"outer:200 OK",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("unexpected middleware calls: %v", calls)
+	}
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Partner"); got != "acme" {
+			t.Errorf("unexpected X-Partner header: %q", got)
+		}
+		_ = json.NewEncoder(w).Encode([]Claim{})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	WithMiddleware(HeaderMiddleware(http.Header{"X-Partner": {"acme"}}))(client)
+
+	if _, err := NewClaimsService(client).GetClaims(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]Claim{{Filenumber: 5}})
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	client := &ClientSettings{
+		AuthToken:  "secret-token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	WithMiddleware(LoggingMiddleware(log.New(&buf, "", 0)))(client)
+
+	if _, err := NewClaimsService(client).GetSingleClaim(context.Background(), 5); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "GetSingleClaim") || !strings.Contains(out, "/getclaims/5") || !strings.Contains(out, "200") {
+		t.Fatalf("unexpected log output: %q", out)
+	}
+	if strings.Contains(out, "secret-token") {
+		t.Fatalf("log output leaked the auth token: %q", out)
+	}
+}
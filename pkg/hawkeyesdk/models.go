@@ -79,14 +79,16 @@ const (
 
 type DocFile struct {
 	Doctype   DocType `json:"doctype"`
-	DateAdded string  `json:"dateadded"`
+	DateAdded Date    `json:"dateadded"`
 	User      string  `json:"user"`
 	Notes     *string `json:"notes,omitempty"`
 	Filename  string  `json:"filename"`
+
+	rawDoctype string
 }
 
 type LogTrail struct {
-	Date     string `json:"date"`
+	Date     Date   `json:"date"`
 	Activity string `json:"activity"`
 	User     string `json:"user"`
 }
@@ -107,7 +109,7 @@ type Claim struct {
 	InsuranceCompany     string     `json:"insurancecompany,omitempty"`
 	ClaimNumber          string     `json:"claimnumber,omitempty"`
 	PolicyNumber         string     `json:"policynumber,omitempty"`
-	DateOfLoss           string     `json:"dateofloss,omitempty"`
+	DateOfLoss           Date       `json:"dateofloss,omitzero"`
 	Adjuster             string     `json:"adjuster,omitempty"`
 	AdjusterPhone        string     `json:"adjusterphone,omitempty"`
 	FirstParty           bool       `json:"firstparty,omitempty"`
@@ -124,32 +126,32 @@ type Claim struct {
 	Color                string     `json:"color,omitempty"`
 	PlateNumber          string     `json:"platenumber,omitempty"`
 	UnitNumber           string     `json:"unitnumber,omitempty"`
-	InspectionDate       string     `json:"inspectiondate,omitempty"`
-	EstimateAmount       float32    `json:"estimateamount,omitempty"`
+	InspectionDate       Date       `json:"inspectiondate,omitzero"`
+	EstimateAmount       Money      `json:"estimateamount,omitzero"`
 	TotalLoss            bool       `json:"totalloss,omitempty"`
-	ContinuedRentalAmt   float32    `json:"continuedrentalamt,omitempty"`
-	DVAmt                float32    `json:"dv_amt,omitempty"`
+	ContinuedRentalAmt   Money      `json:"continuedrentalamt,omitzero"`
+	DVAmt                Money      `json:"dv_amt,omitzero"`
 	LiabilityAccepted    string     `json:"liabilityaccepted,omitempty"`
 	LiabilityDenied      string     `json:"liabilitydenied,omitempty"`
-	SettlementPD         float32    `json:"settlement_pd,omitempty"`
-	SettlementSalvage    float32    `json:"settlement_salvage,omitempty"`
-	SettlementCR         float32    `json:"settlement_cr,omitempty"`
-	SettlementDV         float32    `json:"settlement_dv,omitempty"`
-	SettlementOther      float32    `json:"settlement_other,omitempty"`
-	SettlementDeductible float32    `json:"settlement_deductable,omitempty"`
-	AdministrativeFee    float32    `json:"administrativefee,omitempty"`
-	AppraisalFee         float32    `json:"appraisalfee,omitempty"`
-	DateFileClosed       string     `json:"datefileclosed,omitempty"`
-	SettlementOffer      float32    `json:"settlementoffer,omitempty"`
-	Supplement           float32    `json:"supplement,omitempty"`
-	SettlementTowing     float32    `json:"settlementtowing,omitempty"`
-	SettlementStorage    float32    `json:"settlementstorage,omitempty"`
-	DemandAdminFee       float32    `json:"demand_admin_fee,omitempty"`
-	DemandAppraisalFee   float32    `json:"demand_appraisal_fee,omitempty"`
-	EstimatedDate        string     `json:"estimateddate,omitempty"`
-	DemandDate           string     `json:"demandate,omitempty"`
-	PolicyStartDate      string     `json:"policystartdate,omitempty"`
-	PolicyEndDate        string     `json:"policyenddate,omitempty"`
+	SettlementPD         Money      `json:"settlement_pd,omitzero"`
+	SettlementSalvage    Money      `json:"settlement_salvage,omitzero"`
+	SettlementCR         Money      `json:"settlement_cr,omitzero"`
+	SettlementDV         Money      `json:"settlement_dv,omitzero"`
+	SettlementOther      Money      `json:"settlement_other,omitzero"`
+	SettlementDeductible Money      `json:"settlement_deductable,omitzero"`
+	AdministrativeFee    Money      `json:"administrativefee,omitzero"`
+	AppraisalFee         Money      `json:"appraisalfee,omitzero"`
+	DateFileClosed       Date       `json:"datefileclosed,omitzero"`
+	SettlementOffer      Money      `json:"settlementoffer,omitzero"`
+	Supplement           Money      `json:"supplement,omitzero"`
+	SettlementTowing     Money      `json:"settlementtowing,omitzero"`
+	SettlementStorage    Money      `json:"settlementstorage,omitzero"`
+	DemandAdminFee       Money      `json:"demand_admin_fee,omitzero"`
+	DemandAppraisalFee   Money      `json:"demand_appraisal_fee,omitzero"`
+	EstimatedDate        Date       `json:"estimateddate,omitzero"`
+	DemandDate           Date       `json:"demandate,omitzero"`
+	PolicyStartDate      Date       `json:"policystartdate,omitzero"`
+	PolicyEndDate        Date       `json:"policyenddate,omitzero"`
 	VehicleOwner         string     `json:"vehicleowner,omitempty"`
 	DocFiles             []DocFile  `json:"docfiles,omitempty"`
 	LogTrail             []LogTrail `json:"logtrail,omitempty"`
@@ -281,8 +283,7 @@ func (d DocType) String() string {
 func (d *DocType) UnmarshalJSON(data []byte) error {
 	var i int
 	if err := json.Unmarshal(data, &i); err == nil {
-		*d = DocType(i)
-		return nil
+		return d.UnmarshalText([]byte(strconv.Itoa(i)))
 	}
 
 	var s string
@@ -290,14 +291,7 @@ func (d *DocType) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	s = strings.TrimSpace(s)
-	for dt := DEFAULT; dt <= FINAL_INVOICE; dt++ {
-		if strings.EqualFold(s, dt.String()) {
-			*d = dt
-			return nil
-		}
-	}
-	return fmt.Errorf("invalid document type: %s", s)
+	return d.UnmarshalText([]byte(s))
 }
 
 func parseSanitizedInt(data []byte) (int, error) {
@@ -395,10 +389,10 @@ type AdminClaim struct {
 	AdjusterPhone            string     `json:"adjusterphone,omitempty"`
 	AdjEmail                 string     `json:"adjemail,omitempty"`
 	AdjFax                   string     `json:"adjfax,omitempty"`
-	DateOfLoss               string     `json:"dateofloss,omitempty"`
+	DateOfLoss               Date       `json:"dateofloss,omitzero"`
 	DriverPhone              string     `json:"driverphone,omitempty"`
 	DriverEmail              string     `json:"driveremail,omitempty"`
-	PhysDamPrice             float32    `json:"physdamprice,omitempty"`
+	PhysDamPrice             Money      `json:"physdamprice,omitzero"`
 	LossDescription          string     `json:"lossdescription,omitempty"`
 	DamageDescription        string     `json:"damagedescription,omitempty"`
 	TeamLeaderAdjID          int        `json:"teamleader_adjid,omitempty"`
@@ -406,16 +400,16 @@ type AdminClaim struct {
 	VehMileage               int        `json:"vehmileage,omitempty"`
 	LaborHours               float32    `json:"laborhours,omitempty"`
 	DamageModifier           float32    `json:"damagemodifier,omitempty"`
-	DailyRent                float32    `json:"dailyrent,omitempty"`
+	DailyRent                Money      `json:"dailyrent,omitzero"`
 	VirtualAssID             int        `json:"virtualassid,omitempty"`
 	LossType                 string     `json:"losstype,omitempty"`
 	ReserveCategory          string     `json:"reservecategory,omitempty"`
 	CatastropheDesc          string     `json:"catastrophedesc,omitempty"`
 	Catastrophe              string     `json:"catastrophe,omitempty"`
-	ReserveAmount            float32    `json:"reserveamount,omitempty"`
-	ReserveAmount2           float32    `json:"reserveamount2,omitempty"`
-	ReserveAmount3           float32    `json:"reserveamount3,omitempty"`
-	ReserveAmount4           float32    `json:"reserveamount4,omitempty"`
+	ReserveAmount            Money      `json:"reserveamount,omitzero"`
+	ReserveAmount2           Money      `json:"reserveamount2,omitzero"`
+	ReserveAmount3           Money      `json:"reserveamount3,omitzero"`
+	ReserveAmount4           Money      `json:"reserveamount4,omitzero"`
 	PolicyRequested          bool       `json:"policyrequested,omitempty"`
 	PolicyReceived           bool       `json:"policyreceived,omitempty"`
 	AOB                      bool       `json:"aob,omitempty"`
@@ -437,23 +431,23 @@ type AdminClaim struct {
 	CashCheck                bool       `json:"cashcheck,omitempty"`
 	PoliceReportNumber       string     `json:"policereportnumber,omitempty"`
 	ReportingAgency          string     `json:"reportingagency,omitempty"`
-	EstimateAmount           float32    `json:"estimateamount,omitempty"`
-	LossOfUseAmount          float32    `json:"lossofuseamnt,omitempty"`
-	DVAmount                 float32    `json:"dv_amnt,omitempty"`
+	EstimateAmount           Money      `json:"estimateamount,omitzero"`
+	LossOfUseAmount          Money      `json:"lossofuseamnt,omitzero"`
+	DVAmount                 Money      `json:"dv_amnt,omitzero"`
 	LiabilityAccepted        string     `json:"liabilityaccepted,omitempty"`
 	LiabilityDenied          string     `json:"liabilitydenied,omitempty"`
-	SettlementOffer          float32    `json:"settlementoffer,omitempty"`
-	SettlementCalcPDSupD     float32    `json:"settlementcalcpdsupd,omitempty"`
-	SettlementPD             float32    `json:"settlement_pd,omitempty"`
-	SettlementSalvage        float32    `json:"settlement_salvage,omitempty"`
-	SettlementLOU            float32    `json:"settlement_lou,omitempty"`
-	SettlementDV             float32    `json:"settlement_dv,omitempty"`
-	SettlementOther          float32    `json:"settlement_other,omitempty"`
-	SettlementDeductible     float32    `json:"settlement_deductable,omitempty"`
-	Supplement               float32    `json:"supplement,omitempty"`
+	SettlementOffer          Money      `json:"settlementoffer,omitzero"`
+	SettlementCalcPDSupD     Money      `json:"settlementcalcpdsupd,omitzero"`
+	SettlementPD             Money      `json:"settlement_pd,omitzero"`
+	SettlementSalvage        Money      `json:"settlement_salvage,omitzero"`
+	SettlementLOU            Money      `json:"settlement_lou,omitzero"`
+	SettlementDV             Money      `json:"settlement_dv,omitzero"`
+	SettlementOther          Money      `json:"settlement_other,omitzero"`
+	SettlementDeductible     Money      `json:"settlement_deductable,omitzero"`
+	Supplement               Money      `json:"supplement,omitzero"`
 	ReceivedVia              string     `json:"receivedvia,omitempty"`
-	DateReceived             string     `json:"datereceived,omitempty"`
-	RecordDate               string     `json:"recorddate,omitempty"`
+	DateReceived             Date       `json:"datereceived,omitzero"`
+	RecordDate               Date       `json:"recorddate,omitzero"`
 	AppraiserID              int        `json:"appraiserid,omitempty"`
 	InsuredName              string     `json:"insuredname,omitempty"`
 	InsdAddress1             string     `json:"insdaddress1,omitempty"`
@@ -481,31 +475,31 @@ type AdminClaim struct {
 	ClmtPhone                string     `json:"clmtphone,omitempty"`
 	ClmtPhone2               string     `json:"clmtphone2,omitempty"`
 	ClmtEmail                string     `json:"clmtemail,omitempty"`
-	DateRptDue               string     `json:"daterptdue,omitempty"`
-	NextStatusDue            string     `json:"nextstatusdue,omitempty"`
-	AdjusterDiaryDate        string     `json:"adjusterdiarydate,omitempty"`
+	DateRptDue               Date       `json:"daterptdue,omitzero"`
+	NextStatusDue            Date       `json:"nextstatusdue,omitzero"`
+	AdjusterDiaryDate        Date       `json:"adjusterdiarydate,omitzero"`
 	DaysUntilRptDue          int        `json:"daysuntilrptdue,omitempty"`
 	HCAdjID                  int        `json:"hc_adjid,omitempty"`
 	AssistAdjID              int        `json:"assist_adjid,omitempty"`
-	AmtInv                   float32    `json:"amt_inv,omitempty"`
+	AmtInv                   Money      `json:"amt_inv,omitzero"`
 	HCAdjuster               string     `json:"hcadjuster,omitempty"`
 	HCAjusterEmail           string     `json:"hcajusteremail,omitempty"`
 	HCAssistantAdjuster      string     `json:"hcassistantadjuster,omitempty"`
 	Appraiser                string     `json:"appraiser,omitempty"`
-	AppraiserDeskStandardFee float32    `json:"appraiserdeskstandardfee,omitempty"`
-	AppraiserDeskExoticFee   float32    `json:"appraiserdeskexoticfee,omitempty"`
+	AppraiserDeskStandardFee Money      `json:"appraiserdeskstandardfee,omitzero"`
+	AppraiserDeskExoticFee   Money      `json:"appraiserdeskexoticfee,omitzero"`
 	PlateNumber              string     `json:"platenumber,omitempty"`
 	UnitNumber               string     `json:"unitnumber,omitempty"`
-	AckEmailDateSent         string     `json:"ackemaildatesent,omitempty"`
-	InterimSubmittedAmt      float32    `json:"interimsubmittedamt,omitempty"`
-	InterimInvoiceAmt        float32    `json:"interiminvoiceamt,omitempty"`
-	InspectionDate           string     `json:"inspectiondate,omitempty"`
-	AdministrativeFee        float32    `json:"administrativefee,omitempty"`
-	AppraisalFee             float32    `json:"appraisalfee,omitempty"`
-	DateFileClosed           string     `json:"datefileclosed,omitempty"`
-	SettDamageDeposit        float32    `json:"settdamagedeposit,omitempty"`
-	DemandAdminFee           float32    `json:"demand_admin_fee,omitempty"`
-	DemandAppraisalFee       float32    `json:"demand_appraisal_fee,omitempty"`
+	AckEmailDateSent         Date       `json:"ackemaildatesent,omitzero"`
+	InterimSubmittedAmt      Money      `json:"interimsubmittedamt,omitzero"`
+	InterimInvoiceAmt        Money      `json:"interiminvoiceamt,omitzero"`
+	InspectionDate           Date       `json:"inspectiondate,omitzero"`
+	AdministrativeFee        Money      `json:"administrativefee,omitzero"`
+	AppraisalFee             Money      `json:"appraisalfee,omitzero"`
+	DateFileClosed           Date       `json:"datefileclosed,omitzero"`
+	SettDamageDeposit        Money      `json:"settdamagedeposit,omitzero"`
+	DemandAdminFee           Money      `json:"demand_admin_fee,omitzero"`
+	DemandAppraisalFee       Money      `json:"demand_appraisal_fee,omitzero"`
 	BusinessPhone            string     `json:"businessphone,omitempty"`
 	HomePhone                string     `json:"homephone,omitempty"`
 	MobilePhone              string     `json:"mobilephone,omitempty"`
@@ -514,8 +508,8 @@ type AdminClaim struct {
 	CustomerEmail1           string     `json:"customeremail1,omitempty"`
 	CustomerEmail2           string     `json:"customeremail2,omitempty"`
 	AckEmails                string     `json:"ackemails,omitempty"`
-	ClientHourlyRate         float32    `json:"clienthourlyrate,omitempty"`
-	ClaimRate                float32    `json:"claimrate,omitempty"`
+	ClientHourlyRate         Money      `json:"clienthourlyrate,omitzero"`
+	ClaimRate                Money      `json:"claimrate,omitzero"`
 	HideByDefault            int        `json:"hidebydefault,omitempty"`
 	IsFlat                   bool       `json:"isflat,omitempty"`
 	InsCheckReceived         bool       `json:"inscheckreceived,omitempty"`
@@ -524,39 +518,39 @@ type AdminClaim struct {
 	InsClaim                 string     `json:"insclaim,omitempty"`
 	ClaimStatusName          string     `json:"claimstatusname,omitempty"`
 	InspNotNeeded            bool       `json:"inspnotneeded,omitempty"`
-	ACV                      float32    `json:"acv,omitempty"`
-	SalvageQuote             float32    `json:"salvagequote,omitempty"`
+	ACV                      Money      `json:"acv,omitzero"`
+	SalvageQuote             Money      `json:"salvagequote,omitzero"`
 	RANumber                 string     `json:"ranumber,omitempty"`
-	SettlementTowing         float32    `json:"settlementtowing,omitempty"`
-	SettlementStorage        float32    `json:"settlementstorage,omitempty"`
-	Towing                   float32    `json:"towing,omitempty"`
-	Storage                  float32    `json:"storage,omitempty"`
+	SettlementTowing         Money      `json:"settlementtowing,omitzero"`
+	SettlementStorage        Money      `json:"settlementstorage,omitzero"`
+	Towing                   Money      `json:"towing,omitzero"`
+	Storage                  Money      `json:"storage,omitzero"`
 	SalesRepName             string     `json:"salesrepname,omitempty"`
 	Locked                   bool       `json:"locked,omitempty"`
-	SettlementTotalLoss      float32    `json:"settlement_totalloss,omitempty"`
-	DmgDepCollected          float32    `json:"dmgdepcollected,omitempty"`
-	Deductible               float32    `json:"deductible,omitempty"`
+	SettlementTotalLoss      Money      `json:"settlement_totalloss,omitzero"`
+	DmgDepCollected          Money      `json:"dmgdepcollected,omitzero"`
+	Deductible               Money      `json:"deductible,omitzero"`
 	InvNotes                 string     `json:"invnotes,omitempty"`
 	TowingStorage            string     `json:"towingstorage,omitempty"`
 	Ownership                string     `json:"ownership,omitempty"`
 	PoliceFire               string     `json:"policefire,omitempty"`
 	Salvage                  string     `json:"salvage,omitempty"`
 	UseOfExpert              string     `json:"useofexpert,omitempty"`
-	BodilyInjury             float32    `json:"bodilyinjury,omitempty"`
+	BodilyInjury             Money      `json:"bodilyinjury,omitzero"`
 	DocFiles                 []DocFile  `json:"docfiles,omitempty"`
 	LogTrail                 []LogTrail `json:"logtrail,omitempty"`
 	PaymentMethod            string     `json:"paymentmethod,omitempty"`
-	OpenAgreementDate        string     `json:"openagreementdate,omitempty"`
-	ClosedAgreementDate      string     `json:"closedagreementdate,omitempty"`
-	ReportBeforeRentalDate   string     `json:"reportbeforerentaldate,omitempty"`
-	ReportAfterRentalDate    string     `json:"reportafterrentaldate,omitempty"`
-	ReportDate               string     `json:"reportdate,omitempty"`
-	EndRentalPeriodDate      string     `json:"endrentalperioddate,omitempty"`
-	StartRentalPeriodDate    string     `json:"startrentalperioddate,omitempty"`
+	OpenAgreementDate        Date       `json:"openagreementdate,omitzero"`
+	ClosedAgreementDate      Date       `json:"closedagreementdate,omitzero"`
+	ReportBeforeRentalDate   Date       `json:"reportbeforerentaldate,omitzero"`
+	ReportAfterRentalDate    Date       `json:"reportafterrentaldate,omitzero"`
+	ReportDate               Date       `json:"reportdate,omitzero"`
+	EndRentalPeriodDate      Date       `json:"endrentalperioddate,omitzero"`
+	StartRentalPeriodDate    Date       `json:"startrentalperioddate,omitzero"`
 	BirthYear                int        `json:"birthyear,omitempty"`
-	HandlingStartDate        string     `json:"handlingstartdate,omitempty"`
-	DemandDate               string     `json:"demanddate,omitempty"`
-	PolicyStartDate          string     `json:"policystartdate,omitempty"`
-	PolicyEndDate            string     `json:"policyenddate,omitempty"`
+	HandlingStartDate        Date       `json:"handlingstartdate,omitzero"`
+	DemandDate               Date       `json:"demanddate,omitzero"`
+	PolicyStartDate          Date       `json:"policystartdate,omitzero"`
+	PolicyEndDate            Date       `json:"policyenddate,omitzero"`
 	VehicleOwner             string     `json:"vehicleowner,omitempty"`
 }
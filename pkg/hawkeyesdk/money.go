@@ -0,0 +1,248 @@
+package hawkeyesdk
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount in US cents. Amounts add and subtract exactly
+// with Add, Sub and Sum. It decodes from JSON numbers and from strings such
+// as "1234.56", "$1,234.56" or "($12.00)", and encodes as a JSON number with
+// two decimals.
+//
+// Money is a struct rather than a number so that code written against the
+// former float32 fields, such as claim.SettlementPD > 1000, fails to compile
+// instead of silently comparing cents. Use Float32 or MoneyFromFloat32 to
+// convert.
+type Money struct {
+	cents int64
+}
+
+// Cents returns an amount of cents as Money.
+func Cents(cents int64) Money {
+	return Money{cents: cents}
+}
+
+// Dollars returns a whole number of dollars as Money.
+func Dollars(dollars int64) Money {
+	return Money{cents: dollars * 100}
+}
+
+// MoneyFromFloat rounds f to the nearest cent, half away from zero.
+func MoneyFromFloat(f float64) Money {
+	return Money{cents: int64(math.Round(f * 100))}
+}
+
+// MoneyFromFloat32 converts a legacy float32 amount, rounding to the nearest
+// cent. Amounts above about $100,000 may already have lost cents as float32.
+func MoneyFromFloat32(f float32) Money {
+	return MoneyFromFloat(float64(f))
+}
+
+// ParseMoney parses a decimal amount with an optional sign, currency symbol,
+// thousands separators or accounting parentheses. Digits past the cent are
+// rounded half away from zero.
+func ParseMoney(s string) (Money, error) {
+	text := strings.TrimSpace(s)
+	if text == "" {
+		return Money{}, nil
+	}
+
+	// Parentheses, a leading minus and a minus after the currency symbol
+	// all mean negative; combining them does not cancel out.
+	negative := false
+	if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+		negative = true
+		text = strings.TrimSpace(text[1 : len(text)-1])
+	}
+	signed := false
+	if strings.HasPrefix(text, "-") {
+		negative, signed = true, true
+		text = strings.TrimSpace(text[1:])
+	} else if strings.HasPrefix(text, "+") {
+		signed = true
+		text = strings.TrimSpace(text[1:])
+	}
+	text = strings.TrimSpace(strings.TrimPrefix(text, "$"))
+	if !signed && strings.HasPrefix(text, "-") {
+		negative = true
+		text = text[1:]
+	}
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		return Money{}, fmt.Errorf("invalid money amount %q", s)
+	}
+	text = strings.ReplaceAll(text, ",", "")
+
+	whole, frac, _ := strings.Cut(text, ".")
+	if whole == "" && frac == "" {
+		return Money{}, fmt.Errorf("invalid money amount %q", s)
+	}
+	if strings.ContainsAny(frac, "eE") || strings.ContainsAny(whole, "eE") {
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return Money{}, fmt.Errorf("invalid money amount %q", s)
+		}
+		m := MoneyFromFloat(f)
+		if negative {
+			m = m.Neg()
+		}
+		return m, nil
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("invalid money amount %q", s)
+	}
+
+	var dollars int64
+	if whole != "" {
+		var err error
+		if dollars, err = strconv.ParseInt(whole, 10, 64); err != nil || dollars > math.MaxInt64/100-1 {
+			return Money{}, fmt.Errorf("money amount out of range %q", s)
+		}
+	}
+
+	cents := int64(0)
+	for i := 0; i < 2; i++ {
+		cents *= 10
+		if i < len(frac) {
+			cents += int64(frac[i] - '0')
+		}
+	}
+	if len(frac) > 2 && frac[2] >= '5' {
+		cents++
+	}
+
+	m := Cents(dollars*100 + cents)
+	if negative {
+		m = m.Neg()
+	}
+	return m, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Sum adds amounts exactly.
+func Sum(amounts ...Money) Money {
+	var total Money
+	for _, m := range amounts {
+		total.cents += m.cents
+	}
+	return total
+}
+
+// Add returns m + other.
+func (m Money) Add(other Money) Money {
+	return Money{cents: m.cents + other.cents}
+}
+
+// Sub returns m - other.
+func (m Money) Sub(other Money) Money {
+	return Money{cents: m.cents - other.cents}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{cents: -m.cents}
+}
+
+// Cmp returns -1, 0 or +1 as m is less than, equal to or greater than other.
+func (m Money) Cmp(other Money) int {
+	return cmp.Compare(m.cents, other.cents)
+}
+
+// IsZero reports whether m is zero.
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+// Cents returns m as a number of cents.
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+// Float64 returns m in dollars.
+func (m Money) Float64() float64 {
+	return float64(m.cents) / 100
+}
+
+// Float32 returns m in dollars, for code written against the former float32
+// fields.
+func (m Money) Float32() float32 {
+	return float32(m.Float64())
+}
+
+// Decimal formats m as a plain decimal such as "-1234.56".
+func (m Money) Decimal() string {
+	sign, abs := m.split()
+	return fmt.Sprintf("%s%d.%02d", sign, abs/100, abs%100)
+}
+
+// String formats m for display, e.g. "$1,234.56" or "-$12.00".
+func (m Money) String() string {
+	sign, abs := m.split()
+	dollars := strconv.FormatUint(abs/100, 10)
+	var grouped strings.Builder
+	for i, r := range dollars {
+		if i > 0 && (len(dollars)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(r)
+	}
+	return fmt.Sprintf("%s$%s.%02d", sign, grouped.String(), abs%100)
+}
+
+// split returns the sign and magnitude of m. The magnitude is negated in
+// uint64 so that the smallest int64 does not overflow.
+func (m Money) split() (string, uint64) {
+	if m.cents < 0 {
+		return "-", -uint64(m.cents)
+	}
+	return "", uint64(m.cents)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{}
+		return nil
+	}
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("invalid money amount %s: %w", data, err)
+		}
+	}
+	parsed, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+func (m *Money) UnmarshalText(text []byte) error {
+	parsed, err := ParseMoney(string(text))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
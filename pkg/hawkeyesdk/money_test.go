@@ -0,0 +1,129 @@
+package hawkeyesdk
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]int64{
+		"":             0,
+		"0":            0,
+		"12":           1200,
+		"12.5":         1250,
+		"1234.56":      123456,
+		"$1,234.56":    123456,
+		"-$1,234.56":   -123456,
+		"$-5.00":       -500,
+		"($12.00)":     -1200,
+		"($-5)":        -500,
+		"(-$5)":        -500,
+		"+$5":          500,
+		".99":          99,
+		"10.005":       1001,
+		"-10.005":      -1001,
+		"123456789.01": 12345678901,
+		"1.5e3":        150000,
+		"($1.5e3)":     -150000,
+		" $ 7.10 ":     710,
+	}
+	for input, expected := range cases {
+		got, err := ParseMoney(input)
+		if err != nil || got.Cents() != expected {
+			t.Fatalf("%q parsed as %d, %v; expected %d", input, got.Cents(), err, expected)
+		}
+	}
+
+	for _, input := range []string{"abc", "$", "1.2.3", "12,34a", "--5", "-$-5", "+-5", "--1e3"} {
+		if _, err := ParseMoney(input); err == nil {
+			t.Fatalf("expected %q to fail", input)
+		}
+	}
+}
+
+func TestMoney_Format(t *testing.T) {
+	t.Parallel()
+
+	cases := map[int64]string{
+		0:           "$0.00",
+		5:           "$0.05",
+		123456:      "$1,234.56",
+		-1200:       "-$12.00",
+		100000000:   "$1,000,000.00",
+		12345678901: "$123,456,789.01",
+
+		math.MinInt64: "-$92,233,720,368,547,758.08",
+		math.MaxInt64: "$92,233,720,368,547,758.07",
+	}
+	for cents, expected := range cases {
+		if got := Cents(cents).String(); got != expected {
+			t.Fatalf("%d formatted as %q, expected %q", cents, got, expected)
+		}
+	}
+	if got := Cents(-5).Decimal(); got != "-0.05" {
+		t.Fatalf("unexpected decimal: %q", got)
+	}
+	if got := Cents(math.MinInt64).Decimal(); got != "-92233720368547758.08" {
+		t.Fatalf("unexpected decimal: %q", got)
+	}
+}
+
+func TestMoney_SumsExactly(t *testing.T) {
+	t.Parallel()
+
+	var total Money
+	for i := 0; i < 1000; i++ {
+		total = total.Add(Cents(10))
+	}
+	if total != Dollars(100) {
+		t.Fatalf("expected exact sum, got %s", total)
+	}
+	if Sum(Dollars(100000), Cents(1), Cents(-2)) != Cents(9999999) {
+		t.Fatalf("unexpected sum")
+	}
+	if Dollars(5).Sub(Cents(1)).Neg() != Cents(-499) || Cents(1).Cmp(Cents(2)) != -1 || !(Money{}).IsZero() {
+		t.Fatalf("unexpected arithmetic")
+	}
+	if MoneyFromFloat(0.1+0.2) != Cents(30) {
+		t.Fatalf("expected float conversion to round to the cent")
+	}
+}
+
+func TestClaim_MoneyFields(t *testing.T) {
+	t.Parallel()
+
+	var claim AdminClaim
+	data := `{"settlement_pd":123456.78,"settlement_dv":"$1,234.50","reserveamount":null,"amt_inv":"","laborhours":1.5}`
+	if err := json.Unmarshal([]byte(data), &claim); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if claim.SettlementPD != Cents(12345678) || claim.SettlementDV != Cents(123450) || !claim.ReserveAmount.IsZero() || !claim.AmtInv.IsZero() {
+		t.Fatalf("unexpected amounts: %+v", claim)
+	}
+	if claim.SettlementPD.Float32() != float32(123456.78) {
+		t.Fatalf("unexpected float32 compatibility value: %v", claim.SettlementPD.Float32())
+	}
+
+	out, err := json.Marshal(Claim{SettlementPD: Cents(12345678), DVAmt: Dollars(5)})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var amounts map[string]json.RawMessage
+	if err := json.Unmarshal(out, &amounts); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if string(amounts["settlement_pd"]) != "123456.78" || string(amounts["dv_amt"]) != "5.00" {
+		t.Fatalf("unexpected json: %s", out)
+	}
+	if _, ok := amounts["settlement_dv"]; ok {
+		t.Fatalf("expected zero amounts to be omitted: %s", out)
+	}
+
+	var bad Claim
+	if err := json.Unmarshal([]byte(`{"settlement_pd":"lots"}`), &bad); err == nil {
+		t.Fatalf("expected invalid amount to fail")
+	}
+}
@@ -0,0 +1,434 @@
+package hawkeyesdk
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClaimRecord is satisfied by the claim models a Query can filter.
+type ClaimRecord interface {
+	Claim | AdminClaim
+}
+
+// claimView is the subset of claim fields used by queries and syncs, shared
+// by Claim and AdminClaim.
+type claimView struct {
+	filenumber        int
+	dateOfLoss        Date
+	insuranceCompany  string
+	status            string
+	renterName        string
+	totalLoss         bool
+	liabilityAccepted string
+	searchable        []string
+	dateFileClosed    Date
+	docFiles          []DocFile
+	logTrail          []LogTrail
+}
+
+func (c *Claim) view() claimView {
+	return claimView{
+		filenumber:        c.Filenumber,
+		dateOfLoss:        c.DateOfLoss,
+		insuranceCompany:  c.InsuranceCompany,
+		renterName:        c.RenterName,
+		totalLoss:         c.TotalLoss,
+		liabilityAccepted: c.LiabilityAccepted,
+		searchable: []string{
+			c.RenterName, c.InsuredName, c.CustomerName, c.VehicleOwner,
+			c.VIN, c.PlateNumber, c.UnitNumber, c.ClaimNumber, c.ClientClaimNo, c.RANumber,
+		},
+		dateFileClosed: c.DateFileClosed,
+		docFiles:       c.DocFiles,
+		logTrail:       c.LogTrail,
+	}
+}
+
+func (a *AdminClaim) view() claimView {
+	return claimView{
+		filenumber:        a.Filenumber,
+		dateOfLoss:        a.DateOfLoss,
+		insuranceCompany:  a.InsuranceCompany,
+		status:            a.ClaimStatusName,
+		renterName:        a.RenterName,
+		totalLoss:         a.TotalLoss,
+		liabilityAccepted: a.LiabilityAccepted,
+		searchable: []string{
+			a.RenterName, a.InsuredName, a.CustomerName, a.DriverName, a.ClmtName, a.VehicleOwner,
+			a.VIN, a.PlateNumber, a.UnitNumber, a.ClaimNumber, a.ClientClaimNo, a.RANumber,
+		},
+		dateFileClosed: a.DateFileClosed,
+		docFiles:       a.DocFiles,
+		logTrail:       a.LogTrail,
+	}
+}
+
+func viewOf[T ClaimRecord](record *T) claimView {
+	switch r := any(record).(type) {
+	case *Claim:
+		return r.view()
+	case *AdminClaim:
+		return r.view()
+	}
+	panic("unreachable")
+}
+
+// ClaimField names a claim field used for sorting and grouping.
+type ClaimField int
+
+const (
+	FieldFilenumber ClaimField = iota
+	FieldDateOfLoss
+	FieldLossMonth
+	FieldLossYear
+	FieldInsuranceCompany
+	FieldStatus
+	FieldRenterName
+)
+
+func (f ClaimField) String() string {
+	switch f {
+	case FieldFilenumber:
+		return "Filenumber"
+	case FieldDateOfLoss:
+		return "DateOfLoss"
+	case FieldLossMonth:
+		return "LossMonth"
+	case FieldLossYear:
+		return "LossYear"
+	case FieldInsuranceCompany:
+		return "InsuranceCompany"
+	case FieldStatus:
+		return "Status"
+	case FieldRenterName:
+		return "RenterName"
+	default:
+		return fmt.Sprintf("ClaimField(%d)", int(f))
+	}
+}
+
+// key returns the value of field as a string for grouping. Claims without a
+// date of loss group under "".
+func (v claimView) key(field ClaimField) string {
+	switch field {
+	case FieldFilenumber:
+		return strconv.Itoa(v.filenumber)
+	case FieldDateOfLoss:
+		return dateKey(v.dateOfLoss, DateLayoutISO)
+	case FieldLossMonth:
+		return dateKey(v.dateOfLoss, "2006-01")
+	case FieldLossYear:
+		return dateKey(v.dateOfLoss, "2006")
+	case FieldInsuranceCompany:
+		return strings.TrimSpace(v.insuranceCompany)
+	case FieldStatus:
+		return strings.TrimSpace(v.status)
+	case FieldRenterName:
+		return strings.TrimSpace(v.renterName)
+	default:
+		return ""
+	}
+}
+
+func dateKey(d Date, layout string) string {
+	if d.Time.IsZero() {
+		return ""
+	}
+	return d.Format(layout)
+}
+
+// sortKey is the value of a SortBy field, computed once per claim before
+// sorting. Only the part that matches the field is set.
+type sortKey struct {
+	number int
+	date   time.Time
+	text   string
+}
+
+func (v claimView) sortKey(field ClaimField) sortKey {
+	switch field {
+	case FieldFilenumber:
+		return sortKey{number: v.filenumber}
+	case FieldDateOfLoss, FieldLossMonth, FieldLossYear:
+		return sortKey{date: v.dateOfLoss.Time}
+	default:
+		return sortKey{text: strings.ToLower(v.key(field))}
+	}
+}
+
+func (k sortKey) compare(other sortKey) int {
+	return cmp.Or(
+		cmp.Compare(k.number, other.number),
+		k.date.Compare(other.date),
+		cmp.Compare(k.text, other.text),
+	)
+}
+
+type querySort[T ClaimRecord] struct {
+	field      ClaimField
+	descending bool
+	compare    func(a, b *T) int
+}
+
+// Query filters, sorts and groups claims on the client. Predicates combine
+// with AND; build one by chaining methods on NewQuery. A Query is not safe
+// for concurrent modification but may be shared once built.
+type Query[T ClaimRecord] struct {
+	predicates []func(*T, claimView) bool
+	sorts      []querySort[T]
+}
+
+func NewQuery[T ClaimRecord]() *Query[T] {
+	return &Query[T]{}
+}
+
+func (q *Query[T]) where(p func(*T, claimView) bool) *Query[T] {
+	q.predicates = append(q.predicates, p)
+	return q
+}
+
+// Where adds a custom predicate.
+func (q *Query[T]) Where(p func(*T) bool) *Query[T] {
+	return q.where(func(record *T, _ claimView) bool { return p(record) })
+}
+
+// DateOfLossBetween keeps claims with a date of loss on the days from from
+// through to, inclusive; only the calendar date of each bound is used. A
+// zero bound is open; claims without a date of loss never match.
+func (q *Query[T]) DateOfLossBetween(from, to time.Time) *Query[T] {
+	return q.where(func(_ *T, v claimView) bool {
+		return !v.dateOfLoss.Time.IsZero() && inDayRange(v.dateOfLoss.Time, from, to)
+	})
+}
+
+// InsuranceCompany keeps claims whose carrier equals one of names, ignoring
+// case and surrounding spaces.
+func (q *Query[T]) InsuranceCompany(names ...string) *Query[T] {
+	return q.where(func(_ *T, v claimView) bool {
+		return containsFold(names, v.insuranceCompany)
+	})
+}
+
+// Status keeps claims whose status name equals one of statuses, ignoring
+// case. Only AdminClaim carries a status; Claim never matches.
+func (q *Query[T]) Status(statuses ...string) *Query[T] {
+	return q.where(func(_ *T, v claimView) bool {
+		return containsFold(statuses, v.status)
+	})
+}
+
+func containsFold(values []string, s string) bool {
+	s = strings.TrimSpace(s)
+	return slices.ContainsFunc(values, func(v string) bool {
+		return strings.EqualFold(strings.TrimSpace(v), s)
+	})
+}
+
+// TotalLoss keeps claims whose TotalLoss flag equals totalLoss.
+func (q *Query[T]) TotalLoss(totalLoss bool) *Query[T] {
+	return q.where(func(_ *T, v claimView) bool {
+		return v.totalLoss == totalLoss
+	})
+}
+
+// LiabilityAccepted keeps claims where liability was, or was not, accepted.
+// The API reports acceptance as free text; empty, "0", "no" and "false"
+// count as not accepted.
+func (q *Query[T]) LiabilityAccepted(accepted bool) *Query[T] {
+	return q.where(func(_ *T, v claimView) bool {
+		return liabilityAccepted(v.liabilityAccepted) == accepted
+	})
+}
+
+func liabilityAccepted(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "0", "n", "no", "false":
+		return false
+	default:
+		return true
+	}
+}
+
+// AmountBetween keeps claims where amount is within [low, high].
+//
+//	q.AmountBetween(func(c *AdminClaim) Money { return c.SettlementPD }, Dollars(1000), Dollars(10000))
+func (q *Query[T]) AmountBetween(amount func(*T) Money, low, high Money) *Query[T] {
+	return q.where(func(record *T, _ claimView) bool {
+		m := amount(record)
+		return m.Cmp(low) >= 0 && m.Cmp(high) <= 0
+	})
+}
+
+// AmountAtLeast keeps claims where amount is low or more.
+func (q *Query[T]) AmountAtLeast(amount func(*T) Money, low Money) *Query[T] {
+	return q.where(func(record *T, _ claimView) bool {
+		return amount(record).Cmp(low) >= 0
+	})
+}
+
+// AmountAtMost keeps claims where amount is high or less.
+func (q *Query[T]) AmountAtMost(amount func(*T) Money, high Money) *Query[T] {
+	return q.where(func(record *T, _ claimView) bool {
+		return amount(record).Cmp(high) <= 0
+	})
+}
+
+// Search keeps claims where every whitespace-separated term of text appears,
+// ignoring case, in the renter, insured, customer, driver, claimant or owner
+// names, the VIN, plate or unit number, or the claim or RA numbers.
+func (q *Query[T]) Search(text string) *Query[T] {
+	terms := strings.Fields(strings.ToLower(text))
+	return q.where(func(_ *T, v claimView) bool {
+		for _, term := range terms {
+			if !slices.ContainsFunc(v.searchable, func(field string) bool {
+				return strings.Contains(strings.ToLower(field), term)
+			}) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// SortBy adds a sort key. Later keys break ties of earlier ones; claims
+// without a date of loss sort first when sorting by date.
+func (q *Query[T]) SortBy(field ClaimField, descending bool) *Query[T] {
+	q.sorts = append(q.sorts, querySort[T]{field: field, descending: descending})
+	return q
+}
+
+// SortFunc adds a custom sort key.
+func (q *Query[T]) SortFunc(compare func(a, b *T) int) *Query[T] {
+	q.sorts = append(q.sorts, querySort[T]{compare: compare})
+	return q
+}
+
+// Match reports whether record satisfies every predicate.
+func (q *Query[T]) Match(record *T) bool {
+	v := viewOf(record)
+	for _, p := range q.predicates {
+		if !p(record, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the matching claims in sort order. records is not modified.
+func (q *Query[T]) Filter(records []T) []T {
+	var matched []T
+	for i := range records {
+		if q.Match(&records[i]) {
+			matched = append(matched, records[i])
+		}
+	}
+	q.sort(matched)
+	return matched
+}
+
+// Collect drains it, keeping only matching claims, and returns them in sort
+// order. Only matching claims are held in memory.
+func (q *Query[T]) Collect(it *Iterator[T]) ([]T, error) {
+	defer it.Close()
+
+	var matched []T
+	for it.Next() {
+		record := it.Value()
+		if q.Match(&record) {
+			matched = append(matched, record)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	q.sort(matched)
+	return matched, nil
+}
+
+// sort orders records in place. The SortBy keys of every record are built
+// once up front, and the records are sorted through pointers so comparisons
+// neither allocate nor copy claims.
+func (q *Query[T]) sort(records []T) {
+	if len(q.sorts) == 0 {
+		return
+	}
+
+	type entry struct {
+		record *T
+		keys   []sortKey
+	}
+	n := len(q.sorts)
+	byField := slices.ContainsFunc(q.sorts, func(s querySort[T]) bool { return s.compare == nil })
+	keys := make([]sortKey, len(records)*n)
+	entries := make([]entry, len(records))
+	for i := range records {
+		entries[i] = entry{record: &records[i], keys: keys[i*n : (i+1)*n]}
+		if !byField {
+			continue
+		}
+		v := viewOf(&records[i])
+		for j, s := range q.sorts {
+			if s.compare == nil {
+				entries[i].keys[j] = v.sortKey(s.field)
+			}
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		for j, s := range q.sorts {
+			var c int
+			if s.compare != nil {
+				c = s.compare(a.record, b.record)
+			} else {
+				c = a.keys[j].compare(b.keys[j])
+			}
+			if s.descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	sorted := make([]T, len(records))
+	for i, e := range entries {
+		sorted[i] = *e.record
+	}
+	copy(records, sorted)
+}
+
+// ClaimGroup is one group produced by GroupClaims.
+type ClaimGroup[T ClaimRecord] struct {
+	Key    string
+	Claims []T
+}
+
+// GroupClaims groups records by the value of field, keeping their order
+// within each group. Groups are sorted by key, case-insensitively.
+func GroupClaims[T ClaimRecord](records []T, field ClaimField) []ClaimGroup[T] {
+	index := make(map[string]int)
+	var groups []ClaimGroup[T]
+	for i := range records {
+		key := viewOf(&records[i]).key(field)
+		g, ok := index[key]
+		if !ok {
+			g = len(groups)
+			index[key] = g
+			groups = append(groups, ClaimGroup[T]{Key: key})
+		}
+		groups[g].Claims = append(groups[g].Claims, records[i])
+	}
+	slices.SortFunc(groups, func(a, b ClaimGroup[T]) int {
+		return cmp.Or(
+			cmp.Compare(strings.ToLower(a.Key), strings.ToLower(b.Key)),
+			cmp.Compare(a.Key, b.Key),
+		)
+	})
+	return groups
+}
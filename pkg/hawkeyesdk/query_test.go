@@ -0,0 +1,185 @@
+package hawkeyesdk
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func queryFixture(t *testing.T) []AdminClaim {
+	t.Helper()
+
+	date := func(s string) Date {
+		d, err := ParseDate(s)
+		if err != nil {
+			t.Fatalf("bad fixture date %q: %v", s, err)
+		}
+		return d
+	}
+	return []AdminClaim{
+		{Filenumber: 1, RenterName: "Ann Lee", InsuranceCompany: "State Farm", ClaimStatusName: "Open", DateOfLoss: date("2024-01-10"), SettlementPD: Dollars(5000), VIN: "1HGCM82633A004352"},
+		{Filenumber: 2, RenterName: "bob stone", InsuranceCompany: "GEICO", ClaimStatusName: "Closed", DateOfLoss: date("2024-02-05"), TotalLoss: true, LiabilityAccepted: "Yes", SettlementPD: Dollars(15000)},
+		{Filenumber: 3, RenterName: "Cara Diaz", InsuranceCompany: "state farm ", ClaimStatusName: "open", DateOfLoss: date("2024-02-20"), LiabilityAccepted: "03/01/2024", PlateNumber: "ABC123", SettlementPD: Dollars(800)},
+		{Filenumber: 4, RenterName: "Dan Fox", InsuranceCompany: "Progressive", ClaimStatusName: "Open", LiabilityAccepted: "no"},
+	}
+}
+
+func filenumbers[T ClaimRecord](records []T) []int {
+	var out []int
+	for i := range records {
+		out = append(out, viewOf(&records[i]).filenumber)
+	}
+	return out
+}
+
+func sameInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestQuery_Predicates(t *testing.T) {
+	t.Parallel()
+
+	claims := queryFixture(t)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	endFeb := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	pst := time.FixedZone("PST", -8*60*60)
+
+	cases := []struct {
+		name     string
+		query    *Query[AdminClaim]
+		expected []int
+	}{
+		{"date range", NewQuery[AdminClaim]().DateOfLossBetween(feb, endFeb), []int{2, 3}},
+		{"open ended date", NewQuery[AdminClaim]().DateOfLossBetween(time.Time{}, feb), []int{1}},
+		// Local midnight west of UTC is still February 5th.
+		{"date in another zone", NewQuery[AdminClaim]().DateOfLossBetween(time.Date(2024, 2, 5, 0, 0, 0, 0, pst), time.Date(2024, 2, 5, 23, 0, 0, 0, pst)), []int{2}},
+		{"date to day end", NewQuery[AdminClaim]().DateOfLossBetween(time.Time{}, time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)), []int{1, 2}},
+		{"carrier", NewQuery[AdminClaim]().InsuranceCompany("STATE FARM"), []int{1, 3}},
+		{"status", NewQuery[AdminClaim]().Status("open"), []int{1, 3, 4}},
+		{"total loss", NewQuery[AdminClaim]().TotalLoss(true), []int{2}},
+		{"liability accepted", NewQuery[AdminClaim]().LiabilityAccepted(true), []int{2, 3}},
+		{"liability not accepted", NewQuery[AdminClaim]().LiabilityAccepted(false), []int{1, 4}},
+		{"amount", NewQuery[AdminClaim]().AmountBetween(func(c *AdminClaim) Money { return c.SettlementPD }, Dollars(1000), Dollars(10000)), []int{1}},
+		{"amount at least", NewQuery[AdminClaim]().AmountAtLeast(func(c *AdminClaim) Money { return c.SettlementPD }, Dollars(1000)), []int{1, 2}},
+		{"amount at most", NewQuery[AdminClaim]().AmountAtMost(func(c *AdminClaim) Money { return c.SettlementPD }, Dollars(1000)), []int{3, 4}},
+		{"amount up to zero", NewQuery[AdminClaim]().AmountBetween(func(c *AdminClaim) Money { return c.SettlementPD }, Dollars(-100), Money{}), []int{4}},
+		{"search name", NewQuery[AdminClaim]().Search("BOB"), []int{2}},
+		{"search vin", NewQuery[AdminClaim]().Search("a004352"), []int{1}},
+		{"search terms", NewQuery[AdminClaim]().Search("cara abc"), []int{3}},
+		{"combined", NewQuery[AdminClaim]().Status("Open").InsuranceCompany("State Farm").Where(func(c *AdminClaim) bool { return c.Filenumber > 1 }), []int{3}},
+	}
+	for _, tc := range cases {
+		if got := filenumbers(tc.query.Filter(claims)); !sameInts(got, tc.expected) {
+			t.Fatalf("%s: got %v, expected %v", tc.name, got, tc.expected)
+		}
+	}
+}
+
+func TestQuery_Sort(t *testing.T) {
+	t.Parallel()
+
+	claims := queryFixture(t)
+
+	got := filenumbers(NewQuery[AdminClaim]().SortBy(FieldDateOfLoss, true).Filter(claims))
+	if !sameInts(got, []int{3, 2, 1, 4}) {
+		t.Fatalf("unexpected date order: %v", got)
+	}
+
+	got = filenumbers(NewQuery[AdminClaim]().SortBy(FieldInsuranceCompany, false).SortBy(FieldFilenumber, true).Filter(claims))
+	if !sameInts(got, []int{2, 4, 3, 1}) {
+		t.Fatalf("unexpected carrier order: %v", got)
+	}
+
+	got = filenumbers(NewQuery[AdminClaim]().SortFunc(func(a, b *AdminClaim) int {
+		return b.SettlementPD.Cmp(a.SettlementPD)
+	}).Filter(claims))
+	if !sameInts(got, []int{2, 1, 3, 4}) {
+		t.Fatalf("unexpected custom order: %v", got)
+	}
+
+	got = filenumbers(NewQuery[AdminClaim]().SortFunc(func(a, b *AdminClaim) int {
+		return cmp.Compare(a.ClaimStatusName, b.ClaimStatusName)
+	}).SortBy(FieldRenterName, true).Filter(claims))
+	if !sameInts(got, []int{2, 4, 1, 3}) {
+		t.Fatalf("unexpected mixed order: %v", got)
+	}
+}
+
+func TestQuery_SortBuildsKeysOnce(t *testing.T) {
+	const n = 1000
+	claims := make([]AdminClaim, n)
+	for i := range claims {
+		claims[i] = AdminClaim{Filenumber: i, InsuranceCompany: fmt.Sprintf("Carrier %d", (i*7919)%n)}
+	}
+
+	q := NewQuery[AdminClaim]().SortBy(FieldInsuranceCompany, false).SortBy(FieldFilenumber, true)
+	allocs := testing.AllocsPerRun(5, func() { q.sort(claims) })
+	if allocs > 3*n {
+		t.Fatalf("expected allocations to grow with the number of claims, not comparisons, got %v", allocs)
+	}
+}
+
+func TestGroupClaims(t *testing.T) {
+	t.Parallel()
+
+	groups := GroupClaims(queryFixture(t), FieldLossMonth)
+	if len(groups) != 3 {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+	if groups[0].Key != "" || groups[1].Key != "2024-01" || groups[2].Key != "2024-02" {
+		t.Fatalf("unexpected group keys: %q %q %q", groups[0].Key, groups[1].Key, groups[2].Key)
+	}
+	if !sameInts(filenumbers(groups[2].Claims), []int{2, 3}) {
+		t.Fatalf("unexpected february claims: %v", filenumbers(groups[2].Claims))
+	}
+
+	claims := []Claim{{Filenumber: 1, InsuranceCompany: "B"}, {Filenumber: 2, InsuranceCompany: "a"}, {Filenumber: 3, InsuranceCompany: "B"}}
+	byCarrier := GroupClaims(claims, FieldInsuranceCompany)
+	if len(byCarrier) != 2 || byCarrier[0].Key != "a" || !sameInts(filenumbers(byCarrier[1].Claims), []int{1, 3}) {
+		t.Fatalf("unexpected carrier groups: %+v", byCarrier)
+	}
+}
+
+func TestQuery_CollectFromIterator(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]Claim{
+			{Filenumber: 1, TotalLoss: true, RenterName: "Zed"},
+			{Filenumber: 2},
+			{Filenumber: 3, TotalLoss: true, RenterName: "Amy"},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	claims, err := NewQuery[Claim]().TotalLoss(true).SortBy(FieldRenterName, false).Collect(NewClaimsService(client).IterateClaims(context.Background()))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !sameInts(filenumbers(claims), []int{3, 1}) {
+		t.Fatalf("unexpected claims: %v", filenumbers(claims))
+	}
+
+	if NewQuery[Claim]().Status("Open").Match(&claims[0]) {
+		t.Fatalf("expected Claim without a status never to match a status filter")
+	}
+}
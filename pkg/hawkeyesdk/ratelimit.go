@@ -0,0 +1,142 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every service of a client. It is
+// safe for concurrent use.
+type RateLimiter struct {
+	mu           sync.Mutex
+	rate         float64
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// NewRateLimiter allows requestsPerSecond on average with bursts of up to
+// burst requests. A non-positive rate disables throttling, but the limiter
+// still honors pauses requested through Backoff.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.refill(now)
+
+		var wait time.Duration
+		switch {
+		case now.Before(l.blockedUntil):
+			wait = l.blockedUntil.Sub(now)
+		case l.rate <= 0:
+			l.mu.Unlock()
+			return nil
+		case l.tokens >= 1:
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		default:
+			wait = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		}
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// Backoff pauses the limiter for d and empties the bucket. The client calls
+// it when the API answers 429 Too Many Requests.
+func (l *RateLimiter) Backoff(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.refill(now)
+	l.tokens = 0
+	if until := now.Add(d); until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	if l.rate > 0 {
+		elapsed := now.Sub(l.last).Seconds()
+		l.tokens += elapsed * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+}
+
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *ClientSettings) {
+		c.RateLimiter = NewRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithMaxConcurrency caps the number of requests in flight across all
+// services. Values below 1 remove the cap.
+func WithMaxConcurrency(n int) Option {
+	return func(c *ClientSettings) {
+		if n < 1 {
+			c.inFlight = nil
+			return
+		}
+		c.inFlight = make(chan struct{}, n)
+	}
+}
+
+func (c *ClientSettings) acquire(ctx context.Context) (func(), error) {
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-c.inFlight })
+	}, nil
+}
+
+// releaseOnClose frees the in-flight slot once the caller is done with the
+// response body.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.release()
+	return err
+}
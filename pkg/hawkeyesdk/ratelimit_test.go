@@ -0,0 +1,121 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait_ThrottlesAfterBurst(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewRateLimiter(50, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Two requests come from the burst, the other two wait ~20ms each.
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("expected limiter to throttle, took %s", elapsed)
+	}
+}
+
+func TestRateLimiter_Wait_HonorsCancellation(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewRateLimiter(1, 1)
+	limiter.Backoff(time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := limiter.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestClientSettings_MaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var current, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		_ = json.NewEncoder(w).Encode([]Claim{{Filenumber: 1}})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	WithMaxConcurrency(2)(client)
+
+	service := NewClaimsService(client)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := service.GetSingleClaim(context.Background(), 1); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Fatalf("expected at most 2 requests in flight, saw %d", got)
+	}
+}
+
+func TestClientSettings_RateLimiter_BacksOffOn429(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_ = json.NewEncoder(w).Encode(ApiResponse{Message: "throttled"})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	WithRateLimit(100, 10)(client)
+
+	service := NewClaimsService(client)
+
+	if _, err := service.GetClaims(context.Background()); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := service.GetClaims(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected limiter to hold the next call, got %v", err)
+	}
+}
@@ -0,0 +1,350 @@
+package hawkeyesdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// apiRequest describes a single logical call to the Hawkeye API. The body is
+// kept as bytes so the request can be rebuilt for every attempt; streaming
+// bodies are produced by getBody instead.
+type apiRequest struct {
+	operation   string
+	method      string
+	url         string
+	body        []byte
+	getBody     func() (io.Reader, error)
+	contentType string
+	headers     http.Header
+
+	// stream marks a response the caller reads a piece at a time between
+	// other calls. Its concurrency slot is released once the headers
+	// arrive, so requests made while reading it cannot deadlock.
+	stream bool
+
+	// filenumber and payload are only used for logging.
+	filenumber int
+	payload    any
+}
+
+func (r apiRequest) build(ctx context.Context, authToken string) (*http.Request, error) {
+	var body io.Reader
+	switch {
+	case r.getBody != nil:
+		var err error
+		if body, err = r.getBody(); err != nil {
+			return nil, err
+		}
+	case r.body != nil:
+		body = bytes.NewReader(r.body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, r.url, body)
+	if err != nil {
+		if closer, ok := body.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+
+	if sized, ok := body.(interface{ Size() int64 }); ok && r.getBody != nil {
+		req.ContentLength = sized.Size()
+		if req.ContentLength == 0 {
+			req.Body = http.NoBody
+		}
+	}
+
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+	for key, values := range r.headers {
+		req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", authToken))
+
+	return req, nil
+}
+
+// send executes the request according to the client's RetryPolicy and
+// returns the first successful response with its body left open.
+func (c *ClientSettings) send(ctx context.Context, r apiRequest) (*http.Response, error) {
+	c.ensureHTTPClient()
+
+	policy := c.RetryPolicy
+	maxAttempts := 1
+	if policy.allowsMethod(r.method) {
+		maxAttempts = policy.attempts()
+	}
+
+	roundTrip := c.roundTripper()
+	refreshed := false
+	// previous is the failure that caused a retry, reported alongside any
+	// error that prevents the retry, such as a body that cannot be replayed.
+	var previous error
+
+	for attempt := 1; ; attempt++ {
+		token, err := c.token(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		release, err := c.acquire(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+
+		attemptCtx, cancelAttempt := context.WithCancel(ctx)
+		req, err := r.build(attemptCtx, token)
+		if err != nil {
+			cancelAttempt()
+			release()
+			if previous != nil {
+				return nil, fmt.Errorf("failed to create request: %w; previous attempt: %w", err, previous)
+			}
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		stopTimeout := c.startTimeout(req, cancelAttempt)
+
+		start := time.Now()
+		resp, err := roundTrip(Operation{Name: r.operation, Attempt: attempt}, req)
+		elapsed := time.Since(start)
+		if stopTimeout() && err != nil {
+			err = fmt.Errorf("no response within %s: %w", c.Timeout, os.ErrDeadlineExceeded)
+		}
+		switch {
+		case err != nil:
+			cancelAttempt()
+			release()
+		case r.stream:
+			release()
+			resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: cancelAttempt}
+		default:
+			resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: func() {
+				release()
+				cancelAttempt()
+			}}
+		}
+		if err == nil && resp.StatusCode == http.StatusTooManyRequests && c.RateLimiter != nil {
+			pause, ok := parseRetryAfter(resp, time.Now())
+			if !ok {
+				pause = max(policy.backoff(attempt), time.Second)
+			}
+			c.RateLimiter.Backoff(pause)
+		}
+
+		// A rejected token is retried once, immediately, if the token source
+		// has a newer one. This does not count against the retry policy.
+		refresh := false
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && !refreshed {
+			refresh = c.refreshToken(ctx, token)
+		}
+
+		retry := false
+		var wait time.Duration
+		if refresh {
+			retry = true
+			refreshed = true
+			maxAttempts++
+		} else if attempt < maxAttempts && policy.shouldRetry(ctx, resp, err) {
+			wait = policy.backoff(attempt)
+			if retryAfter, ok := parseRetryAfter(resp, time.Now()); ok && retryAfter > wait {
+				wait = retryAfter
+			}
+			deadline, ok := ctx.Deadline()
+			retry = !ok || time.Until(deadline) > wait
+		}
+
+		c.logAttempt(ctx, r, req, attemptResult{
+			attempt:   attempt,
+			resp:      resp,
+			err:       err,
+			elapsed:   elapsed,
+			willRetry: retry,
+		})
+
+		if retry {
+			if err != nil {
+				previous = fmt.Errorf("request failed: %w", err)
+			} else if previous = checkResponse(resp, r.operation); previous == nil {
+				drainBody(resp)
+			}
+			if err := sleepContext(ctx, wait); err != nil {
+				return nil, fmt.Errorf("request failed: %w", err)
+			}
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+
+		if err := checkResponse(resp, r.operation); err != nil {
+			return nil, err
+		}
+
+		return resp, nil
+	}
+}
+
+// startTimeout applies c.Timeout to an attempt: once the request body has
+// been sent, cancel is called unless stop is called within the timeout. stop
+// reports whether the attempt timed out.
+func (c *ClientSettings) startTimeout(req *http.Request, cancel func()) (stop func() bool) {
+	if c.Timeout <= 0 {
+		return func() bool { return false }
+	}
+
+	var mu sync.Mutex
+	var timer *time.Timer
+	stopped, timedOut := false, false
+	arm := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped || timer != nil {
+			return
+		}
+		timer = time.AfterFunc(c.Timeout, func() {
+			mu.Lock()
+			defer mu.Unlock()
+			if !stopped {
+				timedOut = true
+				cancel()
+			}
+		})
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		arm()
+	} else {
+		req.Body = &sentBody{ReadCloser: req.Body, sent: arm}
+	}
+
+	return func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		if timer != nil {
+			timer.Stop()
+		}
+		return timedOut
+	}
+}
+
+// sentBody calls sent once the request body has been read to the end or
+// closed by the transport.
+type sentBody struct {
+	io.ReadCloser
+	once sync.Once
+	sent func()
+}
+
+func (b *sentBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.once.Do(b.sent)
+	}
+	return n, err
+}
+
+func (b *sentBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.sent)
+	return err
+}
+
+// do sends the request and returns the full response body.
+func (c *ClientSettings) do(ctx context.Context, r apiRequest) ([]byte, error) {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return bodyBytes, nil
+}
+
+// doJSON sends the request and decodes the JSON response into v.
+func (c *ClientSettings) doJSON(ctx context.Context, r apiRequest, v any) error {
+	bodyBytes, err := c.do(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	if err := c.decodeJSON(bodyBytes, v); err != nil {
+		return r.decodeError(bodyBytes, err)
+	}
+
+	return nil
+}
+
+// decodeJSON unmarshals data into v and applies WithStrictDocTypes.
+func (c *ClientSettings) decodeJSON(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if c.StrictDocTypes {
+		return CheckDocTypes(v)
+	}
+	return nil
+}
+
+func (r apiRequest) decodeError(body []byte, err error) error {
+	decodeErr := &DecodeError{
+		Operation: r.operation,
+		URL:       r.redactedURL(),
+		Err:       err,
+	}
+	if len(body) > maxErrorBodySize {
+		body, decodeErr.BodyTruncated = body[:maxErrorBodySize], true
+	}
+	decodeErr.Body = bytes.Clone(body)
+	return decodeErr
+}
+
+// notFound reports a successful response that did not contain the requested
+// resource, with the same details as an API 404.
+func (r apiRequest) notFound(format string, args ...any) error {
+	return &APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf(format, args...),
+		Operation:  r.operation,
+		URL:        r.redactedURL(),
+	}
+}
+
+func (r apiRequest) redactedURL() string {
+	if u, err := url.Parse(r.url); err == nil {
+		return u.Redacted()
+	}
+	return r.url
+}
+
+func (p RetryPolicy) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return p.retryableStatus(resp.StatusCode)
+}
+
+func drainBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+}
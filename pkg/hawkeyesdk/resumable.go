@@ -0,0 +1,280 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+const (
+	defaultChunkSize    = 8 << 20
+	defaultChunkRetries = 5
+)
+
+// WithChunkSize sets the chunk size used by resumable uploads.
+func WithChunkSize(size int64) UploadFileOption {
+	return func(opts *uploadFileOptions) {
+		if size > 0 {
+			opts.chunkSize = size
+		}
+	}
+}
+
+// WithChunkRetries sets how many times a resumable upload re-syncs with the
+// server and resumes after a failed chunk before giving up.
+func WithChunkRetries(retries int) UploadFileOption {
+	return func(opts *uploadFileOptions) {
+		if retries >= 0 {
+			opts.chunkRetries = retries
+		}
+	}
+}
+
+// UploadSession tracks a resumable upload. It can be persisted (for example
+// as JSON) and passed to ResumeUpload after a crash or restart.
+type UploadSession struct {
+	ID          string `json:"upload_id"`
+	Filenumber  int    `json:"filenumber"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+
+	// Offset is the number of bytes the server has acknowledged.
+	Offset int64 `json:"offset"`
+}
+
+type uploadSessionStatus struct {
+	ID       string `json:"upload_id"`
+	Offset   int64  `json:"offset"`
+	Complete bool   `json:"complete"`
+	Message  string `json:"message"`
+}
+
+// StartUploadSession registers a resumable upload of size bytes.
+func (s *DocFilesService) StartUploadSession(ctx context.Context, filenumber int, filename, contentType string, size int64, opts ...UploadFileOption) (*UploadSession, error) {
+	options := newUploadFileOptions(opts)
+	options.autoCategorize(filename, contentType, nil)
+
+	type postData struct {
+		Filenumber      int    `json:"filenumber"`
+		Filename        string `json:"filename"`
+		ContentType     string `json:"content_type"`
+		Size            int64  `json:"size"`
+		Category        string `json:"category"`
+		VisibleToClient bool   `json:"visible_to_client"`
+		Notes           string `json:"notes"`
+	}
+
+	payload := postData{
+		Filenumber:      filenumber,
+		Filename:        filename,
+		ContentType:     contentType,
+		Size:            size,
+		Category:        options.category.String(),
+		VisibleToClient: options.visibleToClient,
+		Notes:           options.notes,
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal post data: %w", err)
+	}
+
+	var status uploadSessionStatus
+	if err := s.client.doJSON(ctx, apiRequest{
+		operation:   "StartUploadSession",
+		method:      http.MethodPost,
+		url:         s.client.BaseUrl + "/uploadsessions",
+		body:        jsonData,
+		contentType: "application/json",
+		filenumber:  filenumber,
+		payload:     payload,
+	}, &status); err != nil {
+		return nil, err
+	}
+
+	if status.ID == "" {
+		return nil, newSentinelError(ErrDecode, "upload session response did not include an upload_id")
+	}
+
+	return &UploadSession{
+		ID:          status.ID,
+		Filenumber:  filenumber,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		Offset:      status.Offset,
+	}, nil
+}
+
+// UploadResumable uploads a local file in chunks. If a chunk fails, the
+// upload asks the server for the last acknowledged offset and continues from
+// there, up to the WithChunkRetries limit.
+func (s *DocFilesService) UploadResumable(ctx context.Context, filenumber int, path string, opts ...UploadFileOption) (ApiResponse, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ApiResponse{}, fmt.Errorf("failed to open upload file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return ApiResponse{}, fmt.Errorf("failed to open upload file: %w", err)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	session, err := s.StartUploadSession(ctx, filenumber, filepath.Base(path), contentType, info.Size(), opts...)
+	if err != nil {
+		return ApiResponse{}, err
+	}
+
+	return s.ResumeUpload(ctx, session, f, opts...)
+}
+
+// ResumeUpload sends the remaining chunks of session read from src. The
+// session offset is updated as chunks are acknowledged, so on error it can be
+// saved and resumed later. A 409 response or an acknowledged offset other
+// than the end of the chunk makes it ask the server for the offset and
+// continue from there.
+func (s *DocFilesService) ResumeUpload(ctx context.Context, session *UploadSession, src io.ReaderAt, opts ...UploadFileOption) (ApiResponse, error) {
+	options := newUploadFileOptions(opts)
+
+	// Every chunk may need a resync and chunkRetries retries; anything beyond
+	// that means the server keeps moving the offset back.
+	chunks := (session.Size-session.Offset)/options.chunkSize + 1
+	maxRequests := chunks * int64(options.chunkRetries+2)
+
+	failures := 0
+	for requests := int64(1); ; requests++ {
+		if requests > maxRequests {
+			return ApiResponse{}, fmt.Errorf("upload did not complete after %d chunk requests, stopped at byte %d of %d", maxRequests, session.Offset, session.Size)
+		}
+		if options.progress != nil {
+			options.progress(session.Offset, session.Size)
+		}
+
+		start := session.Offset
+		status, err := s.putChunk(ctx, session, src, options.chunkSize)
+		if err == nil {
+			if status.Complete {
+				session.Offset = max(status.Offset, session.Offset)
+				return completedUpload(session, status, options), nil
+			}
+			if status.Offset != start+min(options.chunkSize, session.Size-start) {
+				if syncErr := s.syncUploadSession(ctx, session); syncErr != nil {
+					return ApiResponse{}, fmt.Errorf("upload offset mismatch at byte %d: %w", start, syncErr)
+				}
+			} else {
+				session.Offset = status.Offset
+			}
+			if session.Offset >= session.Size {
+				return completedUpload(session, status, options), nil
+			}
+			if session.Offset == start {
+				return ApiResponse{}, fmt.Errorf("upload made no progress at byte %d of %d", start, session.Size)
+			}
+			failures = 0
+			continue
+		}
+
+		if ctx.Err() != nil || errors.Is(err, ErrValidation) {
+			return ApiResponse{}, err
+		}
+
+		synced := false
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			// The server holds a different offset; continue from it.
+			if syncErr := s.syncUploadSession(ctx, session); syncErr != nil {
+				return ApiResponse{}, fmt.Errorf("upload offset mismatch at byte %d: %w", start, errors.Join(err, syncErr))
+			}
+			if session.Offset != start {
+				continue
+			}
+			synced = true
+		} else if errors.As(err, &apiErr) && apiErr.StatusCode < 500 && apiErr.StatusCode != http.StatusRequestTimeout && apiErr.StatusCode != http.StatusTooManyRequests {
+			return ApiResponse{}, err
+		}
+
+		failures++
+		if failures > options.chunkRetries {
+			return ApiResponse{}, fmt.Errorf("upload interrupted at byte %d of %d: %w", session.Offset, session.Size, err)
+		}
+
+		if synced {
+			continue
+		}
+		if syncErr := s.syncUploadSession(ctx, session); syncErr != nil && ctx.Err() != nil {
+			return ApiResponse{}, syncErr
+		}
+	}
+}
+
+func completedUpload(session *UploadSession, status uploadSessionStatus, options uploadFileOptions) ApiResponse {
+	if options.progress != nil {
+		options.progress(session.Offset, session.Size)
+	}
+	message := status.Message
+	if message == "" {
+		message = "File uploaded successfully"
+	}
+	return ApiResponse{Filenumber: session.Filenumber, Message: message, Success: true}
+}
+
+func (s *DocFilesService) putChunk(ctx context.Context, session *UploadSession, src io.ReaderAt, chunkSize int64) (uploadSessionStatus, error) {
+	start := session.Offset
+	length := min(chunkSize, session.Size-start)
+	if length < 0 {
+		return uploadSessionStatus{}, newSentinelError(ErrValidation, "upload offset %d is beyond size %d", start, session.Size)
+	}
+
+	var status uploadSessionStatus
+	err := s.client.doJSON(ctx, apiRequest{
+		operation: "UploadChunk",
+		method:    http.MethodPut,
+		url:       s.client.BaseUrl + "/uploadsessions/" + url.PathEscape(session.ID),
+		getBody: func() (io.Reader, error) {
+			return io.NewSectionReader(src, start, length), nil
+		},
+		contentType: "application/octet-stream",
+		headers: http.Header{
+			"Content-Range": {contentRange(start, length, session.Size)},
+		},
+		filenumber: session.Filenumber,
+	}, &status)
+	return status, err
+}
+
+func contentRange(start, length, size int64) string {
+	if length == 0 {
+		return fmt.Sprintf("bytes */%d", size)
+	}
+	return fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, size)
+}
+
+// syncUploadSession refreshes the offset the server has acknowledged.
+func (s *DocFilesService) syncUploadSession(ctx context.Context, session *UploadSession) error {
+	var status uploadSessionStatus
+	if err := s.client.doJSON(ctx, apiRequest{
+		operation:  "GetUploadSession",
+		method:     http.MethodGet,
+		url:        s.client.BaseUrl + "/uploadsessions/" + url.PathEscape(session.ID),
+		filenumber: session.Filenumber,
+	}, &status); err != nil {
+		return err
+	}
+
+	session.Offset = status.Offset
+	return nil
+}
@@ -0,0 +1,262 @@
+package hawkeyesdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeUploadServer stands in for the Hawkeye upload session endpoints. When
+// dropAt is set, the first chunk starting at or after that offset has its
+// connection closed halfway through the body.
+type fakeUploadServer struct {
+	t      *testing.T
+	mu     sync.Mutex
+	size   int64
+	data   bytes.Buffer
+	dropAt int64
+	drops  int
+	chunks int
+}
+
+func (f *fakeUploadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/uploadsessions":
+		var payload struct {
+			Size     int64  `json:"size"`
+			Filename string `json:"filename"`
+			Category string `json:"category"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			f.t.Errorf("failed to decode session payload: %v", err)
+		}
+		f.size = payload.Size
+		_ = json.NewEncoder(w).Encode(uploadSessionStatus{ID: "up-1"})
+
+	case r.Method == http.MethodGet && r.URL.Path == "/uploadsessions/up-1":
+		_ = json.NewEncoder(w).Encode(uploadSessionStatus{ID: "up-1", Offset: int64(f.data.Len())})
+
+	case r.Method == http.MethodPut && r.URL.Path == "/uploadsessions/up-1":
+		var start, end, total int64
+		if _, err := fmt.Sscanf(r.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &total); err != nil {
+			f.t.Errorf("bad content range %q: %v", r.Header.Get("Content-Range"), err)
+		}
+		if start != int64(f.data.Len()) {
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(ApiResponse{Message: "offset mismatch"})
+			return
+		}
+
+		if f.dropAt > 0 && start >= f.dropAt && f.drops == 0 {
+			f.drops++
+			half := make([]byte, (end-start+1)/2)
+			_, _ = io.ReadFull(r.Body, half)
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				f.t.Errorf("hijack failed: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+
+		chunk, _ := io.ReadAll(r.Body)
+		f.data.Write(chunk)
+		f.chunks++
+		offset := int64(f.data.Len())
+		_ = json.NewEncoder(w).Encode(uploadSessionStatus{ID: "up-1", Offset: offset, Complete: offset == f.size})
+
+	default:
+		f.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writeTempUpload(t *testing.T, size int) (string, []byte) {
+	t.Helper()
+
+	content := make([]byte, size)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+	path := filepath.Join(t.TempDir(), "checkin.mp4")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	return path, content
+}
+
+func TestDocFilesService_UploadResumable_RecoversFromDisconnect(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeUploadServer{t: t, dropAt: 2048}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	path, content := writeTempUpload(t, 5000)
+
+	var progress []int64
+	resp, err := NewDocFilesService(client).UploadResumable(
+		context.Background(),
+		12,
+		path,
+		WithCategory(CHECK_IN_VIDEO),
+		WithChunkSize(1024),
+		WithProgress(func(sent, total int64) {
+			if total != 5000 {
+				t.Errorf("unexpected total: %d", total)
+			}
+			progress = append(progress, sent)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !resp.Success || resp.Filenumber != 12 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	if fake.drops != 1 {
+		t.Fatalf("expected one simulated disconnect, got %d", fake.drops)
+	}
+	if !bytes.Equal(fake.data.Bytes(), content) {
+		t.Fatalf("server received %d bytes that do not match the file", fake.data.Len())
+	}
+	if fake.chunks != 5 {
+		t.Fatalf("expected 5 acknowledged chunks, got %d", fake.chunks)
+	}
+	if last := progress[len(progress)-1]; last != 5000 {
+		t.Fatalf("expected final progress 5000, got %d", last)
+	}
+	for i := 1; i < len(progress); i++ {
+		if progress[i] < progress[i-1] {
+			t.Fatalf("progress went backwards: %v", progress)
+		}
+	}
+}
+
+func TestDocFilesService_ResumeUpload_FromSavedSession(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeUploadServer{t: t, dropAt: 1024}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	service := NewDocFilesService(client)
+
+	_, content := writeTempUpload(t, 3000)
+
+	session, err := service.StartUploadSession(context.Background(), 7, "call.mp3", "audio/mpeg", int64(len(content)))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = service.ResumeUpload(context.Background(), session, bytes.NewReader(content), WithChunkSize(1024), WithChunkRetries(0))
+	if err == nil {
+		t.Fatalf("expected interrupted upload to fail without chunk retries")
+	}
+	if session.Offset != 1024 {
+		t.Fatalf("expected session to record acknowledged offset 1024, got %d", session.Offset)
+	}
+
+	saved, err := json.Marshal(session)
+	if err != nil {
+		t.Fatalf("failed to persist session: %v", err)
+	}
+	var restored UploadSession
+	if err := json.Unmarshal(saved, &restored); err != nil {
+		t.Fatalf("failed to restore session: %v", err)
+	}
+
+	if _, err := service.ResumeUpload(context.Background(), &restored, bytes.NewReader(content), WithChunkSize(1024)); err != nil {
+		t.Fatalf("expected resumed upload to succeed, got %v", err)
+	}
+	if !bytes.Equal(fake.data.Bytes(), content) {
+		t.Fatalf("server received %d bytes that do not match the content", fake.data.Len())
+	}
+}
+
+func TestDocFilesService_ResumeUpload_ResyncsOnConflict(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeUploadServer{t: t}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	service := NewDocFilesService(client)
+
+	_, content := writeTempUpload(t, 3000)
+	session, err := service.StartUploadSession(context.Background(), 7, "call.mp3", "audio/mpeg", int64(len(content)))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	fake.data.Write(content[:1024])
+
+	// The saved session is behind the server, which answers 409.
+	if _, err := service.ResumeUpload(context.Background(), session, bytes.NewReader(content), WithChunkSize(1024), WithChunkRetries(0)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !bytes.Equal(fake.data.Bytes(), content) || session.Offset != 3000 {
+		t.Fatalf("expected the upload to continue from the server's offset, got %d bytes at offset %d", fake.data.Len(), session.Offset)
+	}
+}
+
+func TestDocFilesService_ResumeUpload_StopsWithoutProgress(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPut {
+			puts++
+		}
+		// A 2xx response without an offset decodes as offset 0.
+		w.Write([]byte(`{"upload_id": "up-1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	session := &UploadSession{ID: "up-1", Filenumber: 7, Size: 3000}
+	_, err := NewDocFilesService(client).ResumeUpload(context.Background(), session, bytes.NewReader(make([]byte, 3000)), WithChunkSize(1024))
+	if err == nil || !strings.Contains(err.Error(), "no progress") {
+		t.Fatalf("expected a no progress error, got %v", err)
+	}
+	if puts != 1 {
+		t.Fatalf("expected one chunk request, got %d", puts)
+	}
+}
@@ -0,0 +1,157 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. The zero value
+// performs a single attempt.
+type RetryPolicy struct {
+	MaxAttempts          int
+	InitialBackoff       time.Duration
+	MaxBackoff           time.Duration
+	Multiplier           float64
+	Jitter               float64
+	RetryableStatusCodes []int
+	RetryableMethods     []string
+
+	// RetryNonIdempotent allows retrying methods outside RetryableMethods,
+	// such as the POST used by CreateClaim. Only enable this when duplicate
+	// submissions are acceptable.
+	RetryNonIdempotent bool
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 250 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusRequestTimeout,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableMethods: []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+			http.MethodPut,
+			http.MethodDelete,
+		},
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *ClientSettings) {
+		c.RetryPolicy = policy
+	}
+}
+
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p RetryPolicy) allowsMethod(method string) bool {
+	if p.RetryNonIdempotent {
+		return true
+	}
+	return slices.ContainsFunc(p.RetryableMethods, func(m string) bool {
+		return strings.EqualFold(m, method)
+	})
+}
+
+func (p RetryPolicy) retryableStatus(code int) bool {
+	return slices.Contains(p.RetryableStatusCodes, code)
+}
+
+// backoff returns the delay before the given retry, where retry 1 is the
+// wait after the first failed attempt.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay -= delay * jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
+
+// parseRetryAfter reads a Retry-After header given either as delay seconds
+// or as an HTTP date.
+func parseRetryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		wait := at.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done. It gives up immediately if
+// the context deadline would expire before the wait is over.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
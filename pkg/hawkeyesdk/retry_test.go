@@ -0,0 +1,269 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestClientSettings_Retry_GetSucceedsAfterTransientError(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html>bad gateway</html>"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]Claim{{Filenumber: 42}})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:   "token",
+		BaseUrl:     server.URL,
+		HTTPClient:  server.Client(),
+		RetryPolicy: testRetryPolicy(),
+	}
+
+	claim, err := NewClaimsService(client).GetSingleClaim(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if claim.Filenumber != 42 {
+		t.Fatalf("unexpected filenumber: %d", claim.Filenumber)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestClientSettings_Retry_GivesUpAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(ApiResponse{Message: "down"})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:   "token",
+		BaseUrl:     server.URL,
+		HTTPClient:  server.Client(),
+		RetryPolicy: testRetryPolicy(),
+	}
+
+	_, err := NewClaimsService(client).GetClaims(context.Background())
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestClientSettings_Retry_SkipsNonIdempotentByDefault(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+		_ = json.NewEncoder(w).Encode(ApiResponse{Message: "bad gateway"})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:   "token",
+		BaseUrl:     server.URL,
+		HTTPClient:  server.Client(),
+		RetryPolicy: testRetryPolicy(),
+	}
+
+	if _, err := NewLogTrailsService(client).CreateLogTrail(context.Background(), 1, "note"); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected a single attempt, got %d", got)
+	}
+}
+
+func TestClientSettings_Retry_NonIdempotentOptIn(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode replayed body: %v", err)
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ApiResponse{Message: "logged", Success: true})
+	}))
+	t.Cleanup(server.Close)
+
+	policy := testRetryPolicy()
+	policy.RetryNonIdempotent = true
+
+	client := &ClientSettings{
+		AuthToken:   "token",
+		BaseUrl:     server.URL,
+		HTTPClient:  server.Client(),
+		RetryPolicy: policy,
+	}
+
+	resp, err := NewLogTrailsService(client).CreateLogTrail(context.Background(), 1, "note")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success response")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestClientSettings_Retry_RetryAfterBeyondDeadline(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_ = json.NewEncoder(w).Encode(ApiResponse{Message: "slow down"})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:   "token",
+		BaseUrl:     server.URL,
+		HTTPClient:  server.Client(),
+		RetryPolicy: testRetryPolicy(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := NewClaimsService(client).GetClaims(ctx); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected to give up without waiting, took %s", elapsed)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected a single attempt, got %d", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "7")
+	if got, ok := parseRetryAfter(resp, now); !ok || got != 7*time.Second {
+		t.Fatalf("unexpected delay-seconds result: %s %v", got, ok)
+	}
+
+	resp.Header.Set("Retry-After", now.Add(90*time.Second).Format(http.TimeFormat))
+	if got, ok := parseRetryAfter(resp, now); !ok || got != 90*time.Second {
+		t.Fatalf("unexpected http-date result: %s %v", got, ok)
+	}
+
+	resp.Header.Set("Retry-After", "soon")
+	if _, ok := parseRetryAfter(resp, now); ok {
+		t.Fatalf("expected invalid header to be ignored")
+	}
+}
+
+func TestClientSettings_Timeout(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getclaims/all/false":
+			// Headers arrive late on the first two attempts.
+			if requests.Add(1) <= 2 {
+				time.Sleep(200 * time.Millisecond)
+			}
+			w.Write([]byte(`[]`))
+		case "/getclaims/all/true":
+			// Headers arrive at once, then the body streams slowly.
+			w.Write([]byte(`[{"filenumber":1}`))
+			w.(http.Flusher).Flush()
+			time.Sleep(150 * time.Millisecond)
+			w.Write([]byte(`,{"filenumber":2}]`))
+		case "/uploadfile":
+			io.Copy(io.Discard, r.Body)
+			w.Write([]byte(`{"success":true}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewHawkeyeClient("token", WithTimeout(50*time.Millisecond), WithRetryPolicy(RetryPolicy{}), WithUploadEndpoint("/uploadfile"))
+	client.BaseUrl = server.URL
+	client.HTTPClient = server.Client()
+
+	_, err := client.Claims.GetClaims(context.Background())
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("expected a late response to time out, got %v", err)
+	}
+
+	client.RetryPolicy = testRetryPolicy()
+	if _, err := client.Claims.GetClaims(context.Background()); err != nil || requests.Load() != 3 {
+		t.Fatalf("expected a timed-out attempt to be retried, got %v after %d requests", err, requests.Load())
+	}
+
+	claims, err := client.Claims.GetClaims(context.Background(), WithIncludeInactive(true))
+	if err != nil || len(claims) != 2 {
+		t.Fatalf("expected a slow body not to be cut off, got %d claims, %v", len(claims), err)
+	}
+
+	slow := &slowReader{delay: 40 * time.Millisecond, chunks: 5}
+	if _, err := client.DocFiles.UploadReader(context.Background(), 1, "notes.txt", "text/plain", slow); err != nil {
+		t.Fatalf("expected a slow upload not to be cut off, got %v", err)
+	}
+}
+
+// slowReader returns chunks of up to 1 KiB, each after a delay.
+type slowReader struct {
+	delay  time.Duration
+	chunks int
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if r.chunks == 0 {
+		return 0, io.EOF
+	}
+	r.chunks--
+	time.Sleep(r.delay)
+	return copy(p, strings.Repeat("x", 1024)), nil
+}
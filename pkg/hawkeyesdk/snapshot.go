@@ -0,0 +1,246 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SnapshotStore persists the state ClaimSync compares fetched claims
+// against. Lock must exclude other syncs sharing the store, across processes
+// if the store is shared across processes, until the returned unlock is
+// called. Load returns nil data when nothing has been saved yet.
+type SnapshotStore interface {
+	Load(ctx context.Context) ([]byte, error)
+	Save(ctx context.Context, data []byte) error
+	Lock(ctx context.Context) (unlock func() error, err error)
+}
+
+// FileSnapshotStore keeps the snapshot in a single file, replaced atomically
+// on save. Lock creates Path+".lock" exclusively and writes a token naming
+// its owner. While the lock is held its modification time is refreshed
+// every StaleLockAfter/4, so a lock file that has not been touched for
+// StaleLockAfter is assumed to belong to a crashed process and is taken
+// over. Unlock removes the lock file only if it still holds the owner's
+// token.
+type FileSnapshotStore struct {
+	Path           string
+	StaleLockAfter time.Duration
+	PollInterval   time.Duration
+}
+
+const (
+	defaultStaleLockAfter   = time.Hour
+	defaultLockPollInterval = 200 * time.Millisecond
+)
+
+func NewFileSnapshotStore(path string) *FileSnapshotStore {
+	return &FileSnapshotStore{Path: path}
+}
+
+func (f *FileSnapshotStore) Load(ctx context.Context) ([]byte, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	return data, nil
+}
+
+func (f *FileSnapshotStore) Save(ctx context.Context, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.Path); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+func (f *FileSnapshotStore) Lock(ctx context.Context) (func() error, error) {
+	lockPath := f.Path + ".lock"
+	staleAfter := f.StaleLockAfter
+	if staleAfter <= 0 {
+		staleAfter = defaultStaleLockAfter
+	}
+	poll := f.PollInterval
+	if poll <= 0 {
+		poll = defaultLockPollInterval
+	}
+
+	token := fmt.Sprintf("%016x%016x", rand.Uint64(), rand.Uint64())
+
+	for {
+		lock, err := os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err == nil {
+			_, err = fmt.Fprintf(lock, "%s %d %s\n", token, os.Getpid(), time.Now().UTC().Format(time.RFC3339))
+			if closeErr := lock.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(lockPath)
+				return nil, fmt.Errorf("failed to lock snapshot: %w", err)
+			}
+			return holdLockFile(lockPath, token, staleAfter), nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("failed to lock snapshot: %w", err)
+		}
+
+		if owner, info, err := readLockFile(lockPath); err == nil && time.Since(info.ModTime()) > staleAfter {
+			// Only the stale lock that was read is removed, not one another
+			// process has created since.
+			if _, err := removeLockFile(lockPath, func(o string, i fs.FileInfo) bool {
+				return o == owner && time.Since(i.ModTime()) > staleAfter
+			}); err != nil {
+				return nil, fmt.Errorf("failed to lock snapshot: %w", err)
+			}
+			continue
+		}
+
+		if err := sleepContext(ctx, poll); err != nil {
+			return nil, fmt.Errorf("failed to lock snapshot: %w", err)
+		}
+	}
+}
+
+// holdLockFile refreshes the lock file's modification time until the
+// returned unlock is called, and stops early if the lock is taken over.
+func holdLockFile(lockPath, token string, staleAfter time.Duration) func() error {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(max(staleAfter/4, time.Millisecond))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			owner, _, err := readLockFile(lockPath)
+			if err != nil {
+				continue
+			}
+			if owner != token {
+				return
+			}
+			now := time.Now()
+			_ = os.Chtimes(lockPath, now, now)
+		}
+	}()
+
+	var once sync.Once
+	var err error
+	return func() error {
+		once.Do(func() {
+			close(done)
+			<-stopped
+			removed, removeErr := removeLockFile(lockPath, func(owner string, _ fs.FileInfo) bool {
+				return owner == token
+			})
+			switch {
+			case removeErr != nil:
+				err = fmt.Errorf("failed to unlock snapshot: %w", removeErr)
+			case !removed:
+				err = errors.New("failed to unlock snapshot: the lock was taken over by another process")
+			}
+		})
+		return err
+	}
+}
+
+// readLockFile returns the owner token and file info of a lock file.
+func readLockFile(path string) (string, fs.FileInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	owner, _, _ := strings.Cut(string(data), " ")
+	return owner, info, nil
+}
+
+// removeLockFile moves the lock file aside, which only one process can do,
+// and deletes it if owned reports it is the expected lock. Otherwise the
+// file is linked back in place, unless a new lock has been created
+// meanwhile. It reports whether the lock file was removed.
+func removeLockFile(lockPath string, owned func(owner string, info fs.FileInfo) bool) (bool, error) {
+	aside := lockPath + "." + strconv.FormatUint(rand.Uint64(), 36) + ".stale"
+	if err := os.Rename(lockPath, aside); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer os.Remove(aside)
+
+	owner, info, err := readLockFile(aside)
+	if err == nil && owned(owner, info) {
+		return true, nil
+	}
+	if err := os.Link(aside, lockPath); err != nil && !errors.Is(err, fs.ErrExist) {
+		return false, err
+	}
+	return false, nil
+}
+
+// MemorySnapshotStore keeps the snapshot in memory, for tests and for
+// processes that sync into a store of their own.
+type MemorySnapshotStore struct {
+	mu   sync.Mutex
+	data []byte
+	lock chan struct{}
+	once sync.Once
+}
+
+func (m *MemorySnapshotStore) Load(ctx context.Context) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]byte(nil), m.data...), nil
+}
+
+func (m *MemorySnapshotStore) Save(ctx context.Context, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data = append([]byte(nil), data...)
+	return nil
+}
+
+func (m *MemorySnapshotStore) Lock(ctx context.Context) (func() error, error) {
+	m.once.Do(func() { m.lock = make(chan struct{}, 1) })
+	select {
+	case m.lock <- struct{}{}:
+		var once sync.Once
+		return func() error {
+			once.Do(func() { <-m.lock })
+			return nil
+		}, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to lock snapshot: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,117 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileSnapshotStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewFileSnapshotStore(filepath.Join(t.TempDir(), "snapshot.json"))
+	store.PollInterval = 10 * time.Millisecond
+	ctx := context.Background()
+
+	data, err := store.Load(ctx)
+	if err != nil || data != nil {
+		t.Fatalf("expected empty store, got %q, %v", data, err)
+	}
+	if err := store.Save(ctx, []byte(`{"version":1}`)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if data, err := store.Load(ctx); err != nil || string(data) != `{"version":1}` {
+		t.Fatalf("unexpected snapshot %q, %v", data, err)
+	}
+
+	unlock, err := store.Lock(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := store.Lock(timeout); err == nil {
+		t.Fatalf("expected second lock to fail while held")
+	}
+	if err := unlock(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// A lock left behind by a crashed process is taken over once stale.
+	if _, err := store.Lock(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	store.StaleLockAfter = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	unlock, err = store.Lock(ctx)
+	if err != nil {
+		t.Fatalf("expected stale lock to be replaced, got %v", err)
+	}
+	unlock()
+}
+
+func TestFileSnapshotStore_LockHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	store := NewFileSnapshotStore(filepath.Join(t.TempDir(), "snapshot.json"))
+	store.StaleLockAfter = 80 * time.Millisecond
+	store.PollInterval = 10 * time.Millisecond
+	ctx := context.Background()
+
+	unlock, err := store.Lock(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	timeout, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+	if _, err := store.Lock(timeout); err == nil {
+		t.Fatalf("expected a held lock to stay fresh past StaleLockAfter")
+	}
+	if err := unlock(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := os.Stat(store.Path + ".lock"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected unlock to remove the lock file, got %v", err)
+	}
+}
+
+func TestFileSnapshotStore_UnlockChecksOwner(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	ctx := context.Background()
+
+	crashed := NewFileSnapshotStore(path)
+	unlockCrashed, err := crashed.Lock(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(path+".lock", old, old); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	other := NewFileSnapshotStore(path)
+	unlockOther, err := other.Lock(ctx)
+	if err != nil {
+		t.Fatalf("expected stale lock to be taken over, got %v", err)
+	}
+
+	if err := unlockCrashed(); err == nil {
+		t.Fatalf("expected unlocking a lock that was taken over to fail")
+	}
+	if _, err := os.Stat(path + ".lock"); err != nil {
+		t.Fatalf("expected the new owner's lock to survive, got %v", err)
+	}
+	if err := unlockOther(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 0 {
+		t.Fatalf("expected no lock files left behind, got %v", entries)
+	}
+}
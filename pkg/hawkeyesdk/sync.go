@@ -0,0 +1,319 @@
+package hawkeyesdk
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type ChangeKind int
+
+const (
+	ClaimCreated ChangeKind = iota
+	ClaimUpdated
+	ClaimClosed
+	ClaimReactivated
+	DocumentAdded
+	LogEntryAdded
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ClaimCreated:
+		return "created"
+	case ClaimUpdated:
+		return "updated"
+	case ClaimClosed:
+		return "closed"
+	case ClaimReactivated:
+		return "reactivated"
+	case DocumentAdded:
+		return "document added"
+	case LogEntryAdded:
+		return "log entry added"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// FieldChange is one changed field of an updated claim, identified by its
+// JSON name. Old or New is nil when the field was absent.
+type FieldChange struct {
+	Field string
+	Old   json.RawMessage
+	New   json.RawMessage
+}
+
+// ChangeEvent describes one change found by ClaimSync. Previous is nil for
+// ClaimCreated; Fields is set for ClaimUpdated; DocFile and LogEntry are set
+// for DocumentAdded and LogEntryAdded.
+type ChangeEvent[T ClaimRecord] struct {
+	Kind       ChangeKind
+	Filenumber int
+	Claim      T
+	Previous   *T
+	Fields     []FieldChange
+	DocFile    *DocFile
+	LogEntry   *LogTrail
+}
+
+const snapshotVersion = 1
+
+type claimSnapshot[T ClaimRecord] struct {
+	Version  int       `json:"version"`
+	SyncedAt time.Time `json:"synced_at"`
+	Claims   map[int]T `json:"claims"`
+}
+
+// ClaimSync mirrors claims incrementally. Each Run fetches the claims,
+// compares them with the stored snapshot and hands every change to a
+// handler. The snapshot is checkpointed as claims are handled, so a run that
+// fails part way resumes without repeating the events already delivered.
+// Runs sharing a store are serialized by its lock.
+type ClaimSync[T ClaimRecord] struct {
+	store SnapshotStore
+	fetch func(ctx context.Context) *Iterator[T]
+
+	// CheckpointEvery saves the snapshot after this many changed claims; zero
+	// saves only at the end of a run or when it fails.
+	CheckpointEvery int
+}
+
+// NewClaimSync syncs admin claims, including inactive claims, documents and
+// log trails so that closures, reactivations and additions are detected.
+// opts are applied after those defaults.
+func NewClaimSync(claims *ClaimsService, store SnapshotStore, opts ...GetAdminClaimsOption) *ClaimSync[AdminClaim] {
+	opts = append([]GetAdminClaimsOption{WithAdminIncludeInactive(true), WithDocFiles(true), WithLogTrail(true)}, opts...)
+	return NewClaimSyncFunc(store, func(ctx context.Context) *Iterator[AdminClaim] {
+		return claims.IterateAdminClaims(ctx, opts...)
+	})
+}
+
+// NewClaimSyncFunc syncs the claims produced by fetch, for example
+// ClaimsService.IterateClaims for non-admin users.
+func NewClaimSyncFunc[T ClaimRecord](store SnapshotStore, fetch func(ctx context.Context) *Iterator[T]) *ClaimSync[T] {
+	return &ClaimSync[T]{store: store, fetch: fetch}
+}
+
+// Run performs one sync. If handle returns an error the run stops, the
+// claims handled so far are checkpointed and the error is returned; the
+// failed claim is compared again on the next run.
+func (s *ClaimSync[T]) Run(ctx context.Context, handle func(ChangeEvent[T]) error) (err error) {
+	unlock, err := s.store.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := unlock(); err == nil && unlockErr != nil {
+			err = fmt.Errorf("failed to unlock snapshot: %w", unlockErr)
+		}
+	}()
+
+	snapshot, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	it := s.fetch(ctx)
+	defer it.Close()
+
+	pending := 0
+	for it.Next() {
+		current := it.Value()
+		filenumber := viewOf(&current).filenumber
+
+		var previous *T
+		if old, ok := snapshot.Claims[filenumber]; ok {
+			previous = &old
+		}
+
+		events, err := diffClaim(previous, current)
+		if err != nil {
+			return s.checkpoint(ctx, snapshot, err)
+		}
+		if len(events) == 0 {
+			continue
+		}
+		for _, event := range events {
+			if err := handle(event); err != nil {
+				return s.checkpoint(ctx, snapshot, err)
+			}
+		}
+
+		snapshot.Claims[filenumber] = current
+		pending++
+		if s.CheckpointEvery > 0 && pending >= s.CheckpointEvery {
+			if err := s.save(ctx, snapshot); err != nil {
+				return err
+			}
+			pending = 0
+		}
+	}
+	if err := it.Err(); err != nil {
+		return s.checkpoint(ctx, snapshot, err)
+	}
+
+	snapshot.SyncedAt = time.Now().UTC()
+	return s.save(ctx, snapshot)
+}
+
+// checkpoint saves the progress of a failed run and returns cause.
+func (s *ClaimSync[T]) checkpoint(ctx context.Context, snapshot *claimSnapshot[T], cause error) error {
+	if err := s.save(context.WithoutCancel(ctx), snapshot); err != nil {
+		return fmt.Errorf("%w (checkpoint failed: %v)", cause, err)
+	}
+	return cause
+}
+
+func (s *ClaimSync[T]) load(ctx context.Context) (*claimSnapshot[T], error) {
+	snapshot := &claimSnapshot[T]{Version: snapshotVersion, Claims: make(map[int]T)}
+	data, err := s.store.Load(ctx)
+	if err != nil || len(data) == 0 {
+		return snapshot, err
+	}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if snapshot.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", snapshot.Version)
+	}
+	if snapshot.Claims == nil {
+		snapshot.Claims = make(map[int]T)
+	}
+	return snapshot, nil
+}
+
+func (s *ClaimSync[T]) save(ctx context.Context, snapshot *claimSnapshot[T]) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return s.store.Save(ctx, data)
+}
+
+// diffClaim returns the events turning previous into current.
+func diffClaim[T ClaimRecord](previous *T, current T) ([]ChangeEvent[T], error) {
+	cur := viewOf(&current)
+	if previous == nil {
+		return []ChangeEvent[T]{{Kind: ClaimCreated, Filenumber: cur.filenumber, Claim: current}}, nil
+	}
+	prev := viewOf(previous)
+
+	fields, err := diffFields(*previous, current)
+	if err != nil {
+		return nil, err
+	}
+
+	newEvent := func(kind ChangeKind) ChangeEvent[T] {
+		return ChangeEvent[T]{Kind: kind, Filenumber: cur.filenumber, Claim: current, Previous: previous}
+	}
+
+	var events []ChangeEvent[T]
+	if len(fields) > 0 {
+		event := newEvent(ClaimUpdated)
+		event.Fields = fields
+		events = append(events, event)
+	}
+	switch {
+	case prev.dateFileClosed.IsZero() && !cur.dateFileClosed.IsZero():
+		events = append(events, newEvent(ClaimClosed))
+	case !prev.dateFileClosed.IsZero() && cur.dateFileClosed.IsZero():
+		events = append(events, newEvent(ClaimReactivated))
+	}
+	for _, doc := range added(prev.docFiles, cur.docFiles, docFileKey) {
+		event := newEvent(DocumentAdded)
+		event.DocFile = &doc
+		events = append(events, event)
+	}
+	for _, entry := range added(prev.logTrail, cur.logTrail, func(l LogTrail) LogTrail { return l }) {
+		event := newEvent(LogEntryAdded)
+		event.LogEntry = &entry
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// added returns the elements of current not in previous, compared by key
+// and counting duplicates.
+func added[E any, K comparable](previous, current []E, key func(E) K) []E {
+	seen := make(map[K]int, len(previous))
+	for _, e := range previous {
+		seen[key(e)]++
+	}
+	var out []E
+	for _, e := range current {
+		if k := key(e); seen[k] > 0 {
+			seen[k]--
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+type docFileIdentity struct {
+	doctype   DocType
+	dateAdded string
+	user      string
+	filename  string
+	notes     string
+}
+
+// docFileKey compares notes by value rather than by pointer.
+func docFileKey(d DocFile) docFileIdentity {
+	key := docFileIdentity{doctype: d.Doctype, dateAdded: d.DateAdded.String(), user: d.User, filename: d.Filename}
+	if d.Notes != nil {
+		key.notes = *d.Notes
+	}
+	return key
+}
+
+// diffFields compares the JSON encodings of two claims field by field.
+// Documents and log trails are reported as separate events.
+func diffFields(previous, current any) ([]FieldChange, error) {
+	oldFields, err := jsonFields(previous)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := jsonFields(current)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for name := range oldFields {
+		names[name] = true
+	}
+	for name := range newFields {
+		names[name] = true
+	}
+	delete(names, "docfiles")
+	delete(names, "logtrail")
+
+	var changes []FieldChange
+	for name := range names {
+		if !bytes.Equal(oldFields[name], newFields[name]) {
+			changes = append(changes, FieldChange{Field: name, Old: oldFields[name], New: newFields[name]})
+		}
+	}
+	slices.SortFunc(changes, func(a, b FieldChange) int {
+		return cmp.Compare(a.Field, b.Field)
+	})
+	return changes, nil
+}
+
+func jsonFields(v any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode claim: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to encode claim: %w", err)
+	}
+	return fields, nil
+}
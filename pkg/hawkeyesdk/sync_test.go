@@ -0,0 +1,158 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newSyncTestServer(t *testing.T, body *atomic.Value) *ClientSettings {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/getadminclaims" || query.Get("includeinactive") != "true" || query.Get("docfiles") != "true" || query.Get("logtrail") != "true" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Write([]byte(body.Load().(string)))
+	}))
+	t.Cleanup(server.Close)
+
+	return &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+}
+
+func runSync(t *testing.T, s *ClaimSync[AdminClaim]) []ChangeEvent[AdminClaim] {
+	t.Helper()
+
+	var events []ChangeEvent[AdminClaim]
+	if err := s.Run(context.Background(), func(e ChangeEvent[AdminClaim]) error {
+		events = append(events, e)
+		return nil
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return events
+}
+
+func TestClaimSync_Run(t *testing.T) {
+	t.Parallel()
+
+	var body atomic.Value
+	body.Store(`[
+		{"filenumber":1,"rentername":"Jane","docfiles":[{"doctype":1,"filename":"a.pdf","notes":"first"}]},
+		{"filenumber":2,"rentername":"John","datefileclosed":"2024-03-01"}
+	]`)
+	client := newSyncTestServer(t, &body)
+	s := NewClaimSync(NewClaimsService(client), &MemorySnapshotStore{})
+
+	events := runSync(t, s)
+	if len(events) != 2 || events[0].Kind != ClaimCreated || events[1].Kind != ClaimCreated || events[0].Previous != nil {
+		t.Fatalf("expected two created events, got %+v", events)
+	}
+
+	if events := runSync(t, s); len(events) != 0 {
+		t.Fatalf("expected no events for unchanged claims, got %+v", events)
+	}
+
+	body.Store(`[
+		{"filenumber":1,"rentername":"Janet","datefileclosed":"2024-05-01",
+		 "docfiles":[{"doctype":1,"filename":"a.pdf","notes":"first"},{"doctype":2,"filename":"b.jpg"}],
+		 "logtrail":[{"date":"05/01/2024","activity":"Closed","user":"adjuster"}]},
+		{"filenumber":2,"rentername":"John"},
+		{"filenumber":3}
+	]`)
+	events = runSync(t, s)
+
+	var kinds []ChangeKind
+	for _, e := range events {
+		kinds = append(kinds, e.Kind)
+	}
+	want := []ChangeKind{ClaimUpdated, ClaimClosed, DocumentAdded, LogEntryAdded, ClaimUpdated, ClaimReactivated, ClaimCreated}
+	if len(kinds) != len(want) {
+		t.Fatalf("expected %v, got %v", want, kinds)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, kinds)
+		}
+	}
+
+	updated := events[0]
+	if updated.Previous == nil || updated.Previous.RenterName != "Jane" || updated.Claim.RenterName != "Janet" {
+		t.Fatalf("unexpected claims on update: %+v", updated)
+	}
+	if len(updated.Fields) != 2 || updated.Fields[0].Field != "datefileclosed" || updated.Fields[1].Field != "rentername" {
+		t.Fatalf("unexpected field changes: %+v", updated.Fields)
+	}
+	if string(updated.Fields[1].Old) != `"Jane"` || string(updated.Fields[1].New) != `"Janet"` {
+		t.Fatalf("unexpected renter change: %s -> %s", updated.Fields[1].Old, updated.Fields[1].New)
+	}
+	if events[2].DocFile == nil || events[2].DocFile.Filename != "b.jpg" {
+		t.Fatalf("expected only the new document, got %+v", events[2].DocFile)
+	}
+	if events[3].LogEntry == nil || events[3].LogEntry.Activity != "Closed" {
+		t.Fatalf("unexpected log entry: %+v", events[3].LogEntry)
+	}
+}
+
+func TestClaimSync_ResumesAfterHandlerError(t *testing.T) {
+	t.Parallel()
+
+	var body atomic.Value
+	body.Store(`[{"filenumber":1},{"filenumber":2},{"filenumber":3}]`)
+	client := newSyncTestServer(t, &body)
+	s := NewClaimSync(NewClaimsService(client), NewFileSnapshotStore(filepath.Join(t.TempDir(), "claims.json")))
+
+	errStop := errors.New("stop")
+	var handled []int
+	err := s.Run(context.Background(), func(e ChangeEvent[AdminClaim]) error {
+		if e.Filenumber == 2 {
+			return errStop
+		}
+		handled = append(handled, e.Filenumber)
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+
+	for _, e := range runSync(t, s) {
+		handled = append(handled, e.Filenumber)
+	}
+	if len(handled) != 3 || handled[0] != 1 || handled[1] != 2 || handled[2] != 3 {
+		t.Fatalf("expected each claim to be handled once, got %v", handled)
+	}
+}
+
+func TestClaimSync_Locked(t *testing.T) {
+	t.Parallel()
+
+	var body atomic.Value
+	body.Store(`[]`)
+	client := newSyncTestServer(t, &body)
+	store := &MemorySnapshotStore{}
+	s := NewClaimSync(NewClaimsService(client), store)
+
+	unlock, err := store.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := s.Run(ctx, func(ChangeEvent[AdminClaim]) error { return nil }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a locked store to block the sync, got %v", err)
+	}
+
+	unlock()
+	runSync(t, s)
+}
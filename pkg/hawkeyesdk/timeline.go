@@ -0,0 +1,87 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type TimelineEventKind int
+
+const (
+	TimelineLogTrail TimelineEventKind = iota
+	TimelineDocFile
+)
+
+func (k TimelineEventKind) String() string {
+	switch k {
+	case TimelineLogTrail:
+		return "log trail"
+	case TimelineDocFile:
+		return "document"
+	default:
+		return fmt.Sprintf("TimelineEventKind(%d)", int(k))
+	}
+}
+
+// TimelineEvent is one entry of a claim history. Exactly one of LogTrail and
+// DocFile is set, according to Kind.
+type TimelineEvent struct {
+	Time     time.Time
+	Kind     TimelineEventKind
+	LogTrail *LogTrailEntry
+	DocFile  *DocFile
+}
+
+// Timeline is a claim history sorted chronologically. Events with the same
+// time keep log trail entries before document additions, and undated events
+// come last.
+type Timeline []TimelineEvent
+
+// NewTimeline merges log trail entries and document additions. Events whose
+// date could not be parsed are kept with a zero Time; the raw date is still
+// on the entry or document.
+func NewTimeline(entries []LogTrailEntry, docs []DocFile) Timeline {
+	timeline := make(Timeline, 0, len(entries)+len(docs))
+	for i := range entries {
+		timeline = append(timeline, TimelineEvent{Time: entries[i].Time, Kind: TimelineLogTrail, LogTrail: &entries[i]})
+	}
+	for i := range docs {
+		timeline = append(timeline, TimelineEvent{Time: docs[i].DateAdded.Time, Kind: TimelineDocFile, DocFile: &docs[i]})
+	}
+
+	slices.SortStableFunc(timeline, func(a, b TimelineEvent) int {
+		return compareUndatedLast(a.Time, b.Time)
+	})
+
+	return timeline
+}
+
+// Between returns the events within [from, to]. A zero bound is open.
+// Undated events are left out when either bound is set.
+func (t Timeline) Between(from, to time.Time) Timeline {
+	var events Timeline
+	for _, event := range t {
+		if (from.IsZero() && to.IsZero()) || !event.Time.IsZero() &&
+			(from.IsZero() || !event.Time.Before(from)) && (to.IsZero() || !event.Time.After(to)) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// Timeline fetches a claim with GetSingleClaim, which needs no admin access,
+// and merges its log trail and documents.
+func (s *LogTrailsService) Timeline(ctx context.Context, filenumber int) (Timeline, error) {
+	claim, err := (&ClaimsService{client: s.client}).GetSingleClaim(ctx, filenumber)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]LogTrailEntry, len(claim.LogTrail))
+	for i, trail := range claim.LogTrail {
+		entries[i], _ = trail.Entry()
+	}
+	return NewTimeline(entries, claim.DocFiles), nil
+}
@@ -0,0 +1,99 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLogTrailsService_Timeline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The timeline only needs the claim itself, not admin access.
+		if r.URL.Path != "/getclaims/9" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode([]Claim{{
+			Filenumber: 9,
+			LogTrail: []LogTrail{
+				{Date: mustParseDate(t, "2024-03-01"), Activity: "claim opened"},
+				{Date: mustParseDate(t, "2024-03-04"), Activity: "estimate requested"},
+			},
+			DocFiles: []DocFile{
+				{Doctype: POLICE_REPORT, DateAdded: mustParseDate(t, "03/03/2024"), Filename: "police.pdf"},
+				{Doctype: IMAGES, DateAdded: mustParseDate(t, "2024-03-01"), Filename: "front.jpg"},
+			},
+		}})
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	timeline, err := NewLogTrailsService(client).Timeline(context.Background(), 9)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var order []string
+	for _, event := range timeline {
+		switch event.Kind {
+		case TimelineLogTrail:
+			order = append(order, event.LogTrail.Activity)
+		case TimelineDocFile:
+			order = append(order, event.DocFile.Filename)
+		}
+	}
+	expected := []string{"claim opened", "front.jpg", "police.pdf", "estimate requested"}
+	if len(order) != len(expected) {
+		t.Fatalf("unexpected timeline: %v", order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("unexpected timeline order: %v", order)
+		}
+	}
+
+	window := timeline.Between(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC))
+	if len(window) != 1 || window[0].DocFile.Filename != "police.pdf" {
+		t.Fatalf("unexpected window: %+v", window)
+	}
+}
+
+func TestNewTimeline_UndatedEvents(t *testing.T) {
+	t.Parallel()
+
+	entries := []LogTrailEntry{
+		{Activity: "undated note", RawDate: "someday"},
+		{Time: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), Activity: "called adjuster"},
+	}
+	docs := []DocFile{
+		{DateAdded: Date{raw: "yesterday"}, Filename: "x.pdf"},
+		{DateAdded: mustParseDate(t, "2024-03-01"), Filename: "front.jpg"},
+	}
+
+	timeline := NewTimeline(entries, docs)
+	if len(timeline) != 4 {
+		t.Fatalf("expected undated events to be kept, got %+v", timeline)
+	}
+	if timeline[0].DocFile == nil || timeline[0].DocFile.Filename != "front.jpg" || timeline[1].LogTrail.Activity != "called adjuster" {
+		t.Fatalf("expected dated events first, got %+v", timeline)
+	}
+	if timeline[2].LogTrail.Activity != "undated note" || timeline[3].DocFile.DateAdded.Raw() != "yesterday" {
+		t.Fatalf("expected undated events last with their raw dates, got %+v", timeline[2:])
+	}
+
+	if window := timeline.Between(time.Time{}, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)); len(window) != 2 {
+		t.Fatalf("expected a bounded window to leave out undated events, got %+v", window)
+	}
+	if all := timeline.Between(time.Time{}, time.Time{}); len(all) != 4 {
+		t.Fatalf("expected an open window to keep every event, got %d", len(all))
+	}
+}
@@ -0,0 +1,239 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenSource supplies the bearer token for each request. Implementations
+// must be safe for concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenRefresher is implemented by token sources that can obtain a new token
+// after the API rejects one with a 401. Refresh receives the rejected token
+// and returns its replacement; returning the same token means no newer token
+// is available and the 401 is reported to the caller.
+type TokenRefresher interface {
+	TokenSource
+	Refresh(ctx context.Context, rejected string) (string, error)
+}
+
+// WithTokenSource makes every request ask source for its token instead of
+// using the fixed AuthToken.
+func WithTokenSource(source TokenSource) Option {
+	return func(c *ClientSettings) {
+		c.TokenSource = source
+	}
+}
+
+// token returns the token for the next attempt.
+func (c *ClientSettings) token(ctx context.Context) (string, error) {
+	if c.TokenSource == nil {
+		return c.AuthToken, nil
+	}
+	token, err := c.TokenSource.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get auth token: %w", err)
+	}
+	return token, nil
+}
+
+// refreshToken reports whether a request rejected with token should be sent
+// again with a refreshed one.
+func (c *ClientSettings) refreshToken(ctx context.Context, token string) bool {
+	refresher, ok := c.TokenSource.(TokenRefresher)
+	if !ok {
+		return false
+	}
+	refreshed, err := refresher.Refresh(ctx, token)
+	return err == nil && refreshed != "" && refreshed != token
+}
+
+// StaticToken returns a TokenSource that always returns token.
+func StaticToken(token string) TokenSource {
+	return staticToken(token)
+}
+
+type staticToken string
+
+func (t staticToken) Token(ctx context.Context) (string, error) {
+	if t == "" {
+		return "", ErrNoToken
+	}
+	return string(t), nil
+}
+
+// EnvToken returns a TokenSource that reads the environment variable name on
+// every request, so a token rotated in the environment is picked up without
+// rebuilding the client.
+func EnvToken(name string) TokenSource {
+	return envToken(name)
+}
+
+type envToken string
+
+func (e envToken) Token(ctx context.Context) (string, error) {
+	token := strings.TrimSpace(os.Getenv(string(e)))
+	if token == "" {
+		return "", fmt.Errorf("%w: %s is not set", ErrNoToken, string(e))
+	}
+	return token, nil
+}
+
+func (e envToken) Refresh(ctx context.Context, rejected string) (string, error) {
+	return e.Token(ctx)
+}
+
+// FileTokenSource reads the token from a file, such as a mounted secret, and
+// rereads it whenever the file's size or modification time changes.
+// Surrounding whitespace is ignored.
+type FileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+func NewFileTokenSource(path string) *FileTokenSource {
+	return &FileTokenSource{path: path}
+}
+
+func (f *FileTokenSource) Token(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.load(false)
+}
+
+// Refresh rereads the file even if it appears unchanged, since some
+// filesystems keep the modification time when a secret is swapped.
+func (f *FileTokenSource) Refresh(ctx context.Context, rejected string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.load(true)
+}
+
+func (f *FileTokenSource) load(force bool) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	if !force && f.token != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("%w: %s is empty", ErrNoToken, f.path)
+	}
+	f.token, f.modTime, f.size = token, info.ModTime(), info.Size()
+	return token, nil
+}
+
+// RefreshFunc obtains a new token and the time it expires. A zero expiry
+// means the token is used until the API rejects it.
+type RefreshFunc func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// RefreshingTokenSource caches the token returned by a RefreshFunc and calls
+// it again shortly before the token expires or after the API rejects it.
+// Concurrent requests share a single refresh. A caller whose context ends
+// stops waiting for it, but the refresh itself carries on for the others,
+// so the RefreshFunc should bound its own duration.
+type RefreshingTokenSource struct {
+	refresh RefreshFunc
+
+	// EarlyExpiry refreshes the token this long before its expiry, so that
+	// requests in flight do not carry a token that expires on the way.
+	EarlyExpiry time.Duration
+
+	mu       sync.Mutex
+	token    string
+	expiry   time.Time
+	inFlight *tokenRefresh
+}
+
+// tokenRefresh is a refresh shared by every caller waiting for it. token
+// and err are set before done is closed.
+type tokenRefresh struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+const defaultEarlyExpiry = 30 * time.Second
+
+func NewRefreshingTokenSource(refresh RefreshFunc) *RefreshingTokenSource {
+	return &RefreshingTokenSource{refresh: refresh, EarlyExpiry: defaultEarlyExpiry}
+}
+
+func (r *RefreshingTokenSource) Token(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	if r.token != "" && (r.expiry.IsZero() || time.Until(r.expiry) > r.EarlyExpiry) {
+		token := r.token
+		r.mu.Unlock()
+		return token, nil
+	}
+	return r.wait(ctx)
+}
+
+// Refresh fetches a new token unless another request has already replaced
+// the rejected one.
+func (r *RefreshingTokenSource) Refresh(ctx context.Context, rejected string) (string, error) {
+	r.mu.Lock()
+	if r.token != "" && r.token != rejected {
+		token := r.token
+		r.mu.Unlock()
+		return token, nil
+	}
+	return r.wait(ctx)
+}
+
+// wait joins the refresh in flight, or starts one, and waits for it or for
+// ctx. It is called with r.mu held and releases it.
+func (r *RefreshingTokenSource) wait(ctx context.Context) (string, error) {
+	call := r.inFlight
+	if call == nil {
+		call = &tokenRefresh{done: make(chan struct{})}
+		r.inFlight = call
+		go r.fetch(context.WithoutCancel(ctx), call)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return "", fmt.Errorf("failed to refresh token: %w", ctx.Err())
+	}
+}
+
+func (r *RefreshingTokenSource) fetch(ctx context.Context, call *tokenRefresh) {
+	token, expiry, err := r.refresh(ctx)
+	switch {
+	case err != nil:
+		token, err = "", fmt.Errorf("failed to refresh token: %w", err)
+	case token == "":
+		err = fmt.Errorf("%w: refresh returned an empty token", ErrNoToken)
+	}
+
+	r.mu.Lock()
+	if err == nil {
+		r.token, r.expiry = token, expiry
+	}
+	r.inFlight = nil
+	r.mu.Unlock()
+
+	call.token, call.err = token, err
+	close(call.done)
+}
@@ -0,0 +1,211 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenSources(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := StaticToken("").Token(ctx); !errors.Is(err, ErrNoToken) {
+		t.Fatalf("expected ErrNoToken, got %v", err)
+	}
+
+	t.Setenv("HAWKEYE_TEST_TOKEN", " first\n")
+	env := EnvToken("HAWKEYE_TEST_TOKEN")
+	if token, err := env.Token(ctx); err != nil || token != "first" {
+		t.Fatalf("unexpected token %q, %v", token, err)
+	}
+	os.Setenv("HAWKEYE_TEST_TOKEN", "second")
+	if token, err := env.Token(ctx); err != nil || token != "second" {
+		t.Fatalf("expected rotated token, got %q, %v", token, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("file-one\n"), 0o600); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	file := NewFileTokenSource(path)
+	if token, err := file.Token(ctx); err != nil || token != "file-one" {
+		t.Fatalf("unexpected token %q, %v", token, err)
+	}
+	if err := os.WriteFile(path, []byte("file-two-longer"), 0o600); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if token, err := file.Token(ctx); err != nil || token != "file-two-longer" {
+		t.Fatalf("expected changed file to be reread, got %q, %v", token, err)
+	}
+}
+
+func TestRefreshingTokenSource(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	source := NewRefreshingTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		n := calls.Add(1)
+		return fmt.Sprintf("token-%d", n), time.Now().Add(time.Hour), nil
+	})
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := source.Token(ctx); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single refresh, got %d", calls.Load())
+	}
+
+	if token, _ := source.Refresh(ctx, "token-1"); token != "token-2" {
+		t.Fatalf("expected rejected token to be replaced, got %q", token)
+	}
+	if token, _ := source.Refresh(ctx, "token-1"); token != "token-2" || calls.Load() != 2 {
+		t.Fatalf("expected an already replaced token to be reused, got %q after %d calls", token, calls.Load())
+	}
+
+	source.EarlyExpiry = 2 * time.Hour
+	if token, _ := source.Token(ctx); token != "token-3" {
+		t.Fatalf("expected token close to expiry to be refreshed, got %q", token)
+	}
+}
+
+func TestClientSettings_TokenRefreshAfterUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	var refreshes atomic.Int32
+	source := NewRefreshingTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		if refreshes.Add(1) == 1 {
+			return "stale", time.Time{}, nil
+		}
+		return "fresh", time.Time{}, nil
+	})
+
+	client := NewHawkeyeClient("", WithTokenSource(source), WithRetryPolicy(RetryPolicy{}))
+	client.BaseUrl = server.URL
+	client.HTTPClient = server.Client()
+
+	if _, err := client.Claims.GetClaims(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if requests.Load() != 2 || refreshes.Load() != 2 {
+		t.Fatalf("expected one retry after refresh, got %d requests and %d refreshes", requests.Load(), refreshes.Load())
+	}
+}
+
+func TestClientSettings_UnauthorizedWithoutNewToken(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewHawkeyeClient("", WithTokenSource(NewRefreshingTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		return fmt.Sprintf("token-%d", time.Now().UnixNano()), time.Time{}, nil
+	})))
+	client.BaseUrl = server.URL
+	client.HTTPClient = server.Client()
+
+	_, err := client.Claims.GetClaims(context.Background())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Fatalf("expected the refreshed token to be tried only once, got %d requests", requests.Load())
+	}
+
+	client.TokenSource = StaticToken("token")
+	requests.Store(0)
+	if _, err := client.Claims.GetClaims(context.Background()); !errors.Is(err, ErrUnauthorized) || requests.Load() != 1 {
+		t.Fatalf("expected a static token not to be retried, got %v after %d requests", err, requests.Load())
+	}
+
+	client.TokenSource = EnvToken("HAWKEYE_TEST_UNSET_TOKEN")
+	requests.Store(0)
+	if _, err := client.Claims.GetClaims(context.Background()); !errors.Is(err, ErrNoToken) || requests.Load() != 0 {
+		t.Fatalf("expected ErrNoToken before sending, got %v after %d requests", err, requests.Load())
+	}
+}
+
+func TestRefreshingTokenSource_WaitHonoursContext(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	var calls atomic.Int32
+	source := NewRefreshingTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		calls.Add(1)
+		<-release
+		return "token", time.Time{}, nil
+	})
+
+	timeout, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := source.Token(timeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to end with the context, got %v", err)
+	}
+
+	done := make(chan string)
+	go func() {
+		token, _ := source.Token(context.Background())
+		done <- token
+	}()
+	close(release)
+	if token := <-done; token != "token" || calls.Load() != 1 {
+		t.Fatalf("expected the second caller to join the running refresh, got %q after %d calls", token, calls.Load())
+	}
+}
+
+func TestClientSettings_UnauthorizedUploadReaderKeepsBothErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	var refreshes atomic.Int32
+	source := NewRefreshingTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		return fmt.Sprintf("token-%d", refreshes.Add(1)), time.Time{}, nil
+	})
+	client := NewHawkeyeClient("", WithTokenSource(source), WithUploadEndpoint("/uploadfile"))
+	client.BaseUrl = server.URL
+	client.HTTPClient = server.Client()
+
+	_, err := client.DocFiles.UploadReader(context.Background(), 1, "notes.txt", "text/plain", strings.NewReader("hello"))
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected the 401 to be kept, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "cannot be replayed") {
+		t.Fatalf("expected the replay failure to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+package hawkeyesdk
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	RuleRequired      = "required"
+	RuleRequiredOneOf = "required_one_of"
+	RuleFormat        = "format"
+	RuleLength        = "length"
+	RuleCheckDigit    = "check_digit"
+	RuleRange         = "range"
+	RuleNotInFuture   = "not_in_future"
+)
+
+// FieldError describes a single rule violation on a ClaimPost field.
+type FieldError struct {
+	Field    string
+	JSONName string
+	Rule     string
+	Message  string
+}
+
+// ValidationError lists every field that failed validation. It matches
+// ErrValidation through errors.Is.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var missing, other []string
+	for _, f := range e.Fields {
+		if f.Rule == RuleRequired {
+			missing = append(missing, f.Field)
+		} else {
+			other = append(other, f.Message)
+		}
+	}
+
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, "missing required fields: "+strings.Join(missing, ", "))
+	}
+	parts = append(parts, other...)
+
+	return strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// FieldErrors returns the violations reported for the given Go field name.
+func (e *ValidationError) FieldErrors(field string) []FieldError {
+	var out []FieldError
+	for _, f := range e.Fields {
+		if f.Field == field {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// DateOfLossLayouts lists the formats accepted for ClaimPost.DateOfLoss.
+var DateOfLossLayouts = []string{DateLayoutISO, DateLayoutUS}
+
+// ValidationOption adjusts the rules applied by ValidateForCreate and
+// ValidateForUpdate.
+type ValidationOption func(*claimValidator)
+
+// RequireVINCheckDigit requires a 17 character VIN with a valid North
+// American check digit (the ninth character). It is off by default because
+// VINs from other markets and vehicles built before 1981 do not carry one,
+// and pre-1981 VINs are often shorter.
+func RequireVINCheckDigit() ValidationOption {
+	return func(v *claimValidator) {
+		v.vinCheckDigit = true
+	}
+}
+
+type claimValidator struct {
+	now           time.Time
+	vinCheckDigit bool
+	fields        []FieldError
+}
+
+func newClaimValidator(now time.Time, opts []ValidationOption) *claimValidator {
+	v := &claimValidator{now: now}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+func (v *claimValidator) add(field, rule, format string, args ...any) {
+	v.fields = append(v.fields, FieldError{
+		Field:    field,
+		JSONName: claimPostJSONName(field),
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *claimValidator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
+
+func (v *claimValidator) checkRequired(c ClaimPost) {
+	for _, def := range claimPostRequiredFieldDefs {
+		if strings.TrimSpace(def.accessor(c)) == "" {
+			v.add(def.name, RuleRequired, "%s is required", def.name)
+		}
+	}
+}
+
+// checkFormats validates the format of every non-empty field.
+func (v *claimValidator) checkFormats(c ClaimPost) {
+	if vin := strings.TrimSpace(c.VehVIN); vin != "" {
+		v.checkVIN(vin)
+	}
+
+	if c.VehYear != 0 {
+		maxYear := v.now.Year() + 1
+		if c.VehYear < 1900 || c.VehYear > maxYear {
+			v.add("VehYear", RuleRange, "VehYear must be between 1900 and %d", maxYear)
+		}
+	}
+
+	if dol := strings.TrimSpace(c.DateOfLoss); dol != "" {
+		date, ok := parseDateOfLoss(dol)
+		switch {
+		case !ok:
+			v.add("DateOfLoss", RuleFormat, "DateOfLoss must use YYYY-MM-DD or MM/DD/YYYY")
+		// Allow a day of slack so claims filed ahead of UTC are not rejected.
+		case date.After(v.now.Add(24 * time.Hour)):
+			v.add("DateOfLoss", RuleNotInFuture, "DateOfLoss cannot be in the future")
+		}
+	}
+
+	if email := strings.TrimSpace(c.RenterEmail); email != "" && !isValidEmail(email) {
+		v.add("RenterEmail", RuleFormat, "RenterEmail is not a valid email address")
+	}
+
+	if phone := strings.TrimSpace(c.RenterPhone); phone != "" && !isValidPhone(phone) {
+		v.add("RenterPhone", RuleFormat, "RenterPhone must be a 10 digit phone number")
+	}
+
+	if state := strings.TrimSpace(c.VehLocationState); state != "" && !IsValidStateCode(state) {
+		v.add("VehLocationState", RuleFormat, "VehLocationState must be a two letter US state code")
+	}
+}
+
+func (v *claimValidator) checkVIN(vin string) {
+	vin = strings.ToUpper(vin)
+	switch {
+	case v.vinCheckDigit && len(vin) != 17:
+		v.add("VehVIN", RuleLength, "VehVIN must be 17 characters")
+		return
+	case len(vin) > 17:
+		v.add("VehVIN", RuleLength, "VehVIN must be at most 17 characters")
+		return
+	}
+	for _, r := range vin {
+		if _, ok := vinTransliteration[r]; !ok {
+			v.add("VehVIN", RuleFormat, "VehVIN contains invalid character %q", r)
+			return
+		}
+	}
+	if v.vinCheckDigit && vinCheckDigit(vin) != vin[8] {
+		v.add("VehVIN", RuleCheckDigit, "VehVIN check digit does not match")
+	}
+}
+
+func (c ClaimPost) validateForCreate(now time.Time, opts ...ValidationOption) error {
+	v := newClaimValidator(now, opts)
+	v.checkRequired(c)
+	v.checkFormats(c)
+	return v.err()
+}
+
+// ValidateForUpdate requires FileNumber or ClientClaimNo to identify the
+// claim. Other fields are only checked when set, so partial updates pass.
+func (c ClaimPost) ValidateForUpdate(opts ...ValidationOption) error {
+	return c.validateForUpdate(time.Now(), opts...)
+}
+
+func (c ClaimPost) validateForUpdate(now time.Time, opts ...ValidationOption) error {
+	v := newClaimValidator(now, opts)
+	if c.FileNumber <= 0 && strings.TrimSpace(c.ClientClaimNo) == "" {
+		v.add("FileNumber", RuleRequiredOneOf, "FileNumber or ClientClaimNo is required")
+	}
+	v.checkFormats(c)
+	return v.err()
+}
+
+func claimPostJSONName(field string) string {
+	f, ok := reflect.TypeOf(ClaimPost{}).FieldByName(field)
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+func parseDateOfLoss(s string) (time.Time, bool) {
+	for _, layout := range DateOfLossLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func isValidEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+func isValidPhone(s string) bool {
+	digits := 0
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case strings.ContainsRune(" ()-.+", r):
+		default:
+			return false
+		}
+	}
+	if digits == 11 {
+		return strings.TrimLeft(s, " (+")[0] == '1'
+	}
+	return digits == 10
+}
+
+var vinTransliteration = map[rune]int{
+	'0': 0, '1': 1, '2': 2, '3': 3, '4': 4, '5': 5, '6': 6, '7': 7, '8': 8, '9': 9,
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+var vinWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+func vinCheckDigit(vin string) byte {
+	sum := 0
+	for i, r := range vin {
+		sum += vinTransliteration[r] * vinWeights[i]
+	}
+	rem := sum % 11
+	if rem == 10 {
+		return 'X'
+	}
+	return byte('0' + rem)
+}
+
+var usStateCodes = map[string]struct{}{
+	"AL": {}, "AK": {}, "AZ": {}, "AR": {}, "CA": {}, "CO": {}, "CT": {}, "DE": {}, "FL": {}, "GA": {},
+	"HI": {}, "ID": {}, "IL": {}, "IN": {}, "IA": {}, "KS": {}, "KY": {}, "LA": {}, "ME": {}, "MD": {},
+	"MA": {}, "MI": {}, "MN": {}, "MS": {}, "MO": {}, "MT": {}, "NE": {}, "NV": {}, "NH": {}, "NJ": {},
+	"NM": {}, "NY": {}, "NC": {}, "ND": {}, "OH": {}, "OK": {}, "OR": {}, "PA": {}, "RI": {}, "SC": {},
+	"SD": {}, "TN": {}, "TX": {}, "UT": {}, "VT": {}, "VA": {}, "WA": {}, "WV": {}, "WI": {}, "WY": {},
+	"DC": {}, "PR": {}, "VI": {}, "GU": {}, "AS": {}, "MP": {},
+}
+
+// IsValidStateCode reports whether s is a US state, DC or territory code.
+func IsValidStateCode(s string) bool {
+	_, ok := usStateCodes[strings.ToUpper(strings.TrimSpace(s))]
+	return ok
+}
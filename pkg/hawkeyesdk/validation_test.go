@@ -0,0 +1,154 @@
+package hawkeyesdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func validClaimPost() ClaimPost {
+	return ClaimPost{
+		RenterName:       "Test Renter",
+		RenterPhone:      "(555) 123-4567",
+		RenterEmail:      "renter@example.com",
+		InsCompaniesID:   "Hawkeye",
+		DateOfLoss:       "2024-01-01",
+		VehYear:          2020,
+		VehMake:          "Honda",
+		VehModel:         "Accord",
+		VehColor:         "Blue",
+		VehVIN:           "1HGCM82633A004352",
+		VehLocationState: "fl",
+	}
+}
+
+func TestClaimPost_ValidateForCreate_Valid(t *testing.T) {
+	t.Parallel()
+
+	if err := validClaimPost().ValidateForCreate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestClaimPost_ValidateForCreate_FieldErrors(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		mutate func(*ClaimPost)
+		field  string
+		rule   string
+	}{
+		{"missing renter", func(c *ClaimPost) { c.RenterName = " " }, "RenterName", RuleRequired},
+		{"long vin", func(c *ClaimPost) { c.VehVIN = "1HGCM82633A0043521" }, "VehVIN", RuleLength},
+		{"vin letter O", func(c *ClaimPost) { c.VehVIN = "1HGCM82633A00435O" }, "VehVIN", RuleFormat},
+		{"year too old", func(c *ClaimPost) { c.VehYear = 1850 }, "VehYear", RuleRange},
+		{"year too new", func(c *ClaimPost) { c.VehYear = 2030 }, "VehYear", RuleRange},
+		{"date format", func(c *ClaimPost) { c.DateOfLoss = "Jan 1 2024" }, "DateOfLoss", RuleFormat},
+		{"date in future", func(c *ClaimPost) { c.DateOfLoss = "07/04/2024" }, "DateOfLoss", RuleNotInFuture},
+		{"email", func(c *ClaimPost) { c.RenterEmail = "not-an-email" }, "RenterEmail", RuleFormat},
+		{"phone", func(c *ClaimPost) { c.RenterPhone = "12345" }, "RenterPhone", RuleFormat},
+		{"state", func(c *ClaimPost) { c.VehLocationState = "ZZ" }, "VehLocationState", RuleFormat},
+	}
+
+	for _, tc := range cases {
+		claim := validClaimPost()
+		tc.mutate(&claim)
+
+		err := claim.validateForCreate(now)
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("%s: expected *ValidationError, got %v", tc.name, err)
+		}
+		if !errors.Is(err, ErrValidation) {
+			t.Fatalf("%s: expected error to match ErrValidation", tc.name)
+		}
+		if len(validationErr.Fields) != 1 {
+			t.Fatalf("%s: expected one field error, got %+v", tc.name, validationErr.Fields)
+		}
+		got := validationErr.Fields[0]
+		if got.Field != tc.field || got.Rule != tc.rule {
+			t.Fatalf("%s: unexpected field error: %+v", tc.name, got)
+		}
+		if got.JSONName != claimPostJSONName(tc.field) || got.JSONName == "" {
+			t.Fatalf("%s: unexpected json name: %q", tc.name, got.JSONName)
+		}
+	}
+}
+
+func TestClaimPost_ValidateForCreate_VINCheckDigit(t *testing.T) {
+	t.Parallel()
+
+	claim := validClaimPost()
+	claim.VehVIN = "1HGCM82643A004352"
+	if err := claim.ValidateForCreate(); err != nil {
+		t.Fatalf("expected the check digit to be ignored by default, got %v", err)
+	}
+
+	var validationErr *ValidationError
+	if err := claim.ValidateForCreate(RequireVINCheckDigit()); !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if fe := validationErr.FieldErrors("VehVIN"); len(fe) != 1 || fe[0].Rule != RuleCheckDigit {
+		t.Fatalf("unexpected field errors: %+v", validationErr.Fields)
+	}
+
+	// Pre-1981 VINs are shorter and only have to be 17 characters when the
+	// check digit is required.
+	claim.VehVIN = "124379N500001"
+	if err := claim.ValidateForCreate(); err != nil {
+		t.Fatalf("expected a pre-1981 VIN to pass by default, got %v", err)
+	}
+	if err := claim.ValidateForCreate(RequireVINCheckDigit()); !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if fe := validationErr.FieldErrors("VehVIN"); len(fe) != 1 || fe[0].Rule != RuleLength {
+		t.Fatalf("unexpected field errors: %+v", validationErr.Fields)
+	}
+}
+
+func TestClaimPost_ValidateForUpdate(t *testing.T) {
+	t.Parallel()
+
+	err := ClaimPost{Note: "update"}.ValidateForUpdate()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if fe := validationErr.FieldErrors("FileNumber"); len(fe) != 1 || fe[0].Rule != RuleRequiredOneOf {
+		t.Fatalf("unexpected field errors: %+v", validationErr.Fields)
+	}
+
+	if err := (ClaimPost{ClientClaimNo: "ABC-1"}).ValidateForUpdate(); err != nil {
+		t.Fatalf("expected client claim number to identify the claim, got %v", err)
+	}
+
+	if err := (ClaimPost{FileNumber: 10, RenterEmail: "bad"}).ValidateForUpdate(); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected format rules to apply on update, got %v", err)
+	}
+
+	// A partial update is not held to the fields a create requires.
+	if err := (ClaimPost{FileNumber: 10, VehVIN: "WVWZZZ1JZXW000001", Note: "update"}).ValidateForUpdate(); err != nil {
+		t.Fatalf("expected a partial update to pass, got %v", err)
+	}
+}
+
+func TestClaimsService_UpdateClaim_ValidatesBeforeSending(t *testing.T) {
+	t.Parallel()
+
+	client := &ClientSettings{
+		AuthToken:  "token",
+		BaseUrl:    "http://127.0.0.1:0",
+		HTTPClient: http.DefaultClient,
+	}
+
+	_, err := NewClaimsService(client).UpdateClaim(context.Background(), ClaimPost{})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}